@@ -1,4 +1,4 @@
-package rpc
+package nft
 
 import (
 	"crypto/sha256"
@@ -24,7 +24,6 @@ func (a *Asset) Hash() (string, error) {
 	}
 	if len(a.Uris) == 0 {
 		err := fmt.Errorf("There are no URLs to hash on this Asset.")
-		logErr.Println(err)
 		return "", err
 	}
 	hashMap := make(map[string]string)
@@ -33,42 +32,44 @@ func (a *Asset) Hash() (string, error) {
 	for i, u := range a.Uris {
 		s := sha256.New()
 		// Do we have a legal URL, or is it possibly a file path?
-		url, err := url.Parse(u)
-		if err == nil && u.Hostname() != "" {
+		var rc io.ReadCloser
+		if pu, err := url.Parse(u); err == nil && pu.Hostname() != "" {
 			// Retrieve asset from URL.
-			r, err := http.Get(url.String())
+			r, err := http.Get(pu.String())
 			if err != nil {
 				// Couldn't get asset from URL
 				err = fmt.Errorf("Unable to get asset at %s for hashing.", u)
-				logErr.Println(err)
 				return "", err
 			}
-			defer r.Body.Close()
-			// Attempt to get the hash of the asset.
-			_, err := io.Copy(s, r.Body)
+			rc = r.Body
 		} else {
-			// We may have a file path. Error handling comes later.
-			afile, err := os.Open(u)
-			// Attempt to get the hash of the asset.
-			_, err = io.Copy(s, afile)
+			// We may have a file path.
+			f, err := os.Open(u)
+			if err != nil {
+				err = fmt.Errorf("Unable to open asset at %s for hashing.", u)
+				return "", err
+			}
+			rc = f
 		}
+		// Attempt to get the hash of the asset.
+		_, err := io.Copy(s, rc)
+		rc.Close()
 		// Handle hashing error.
 		if err != nil {
 			err = fmt.Errorf("Couldn't read from asset at %s, to get its hash.", u)
-			logErr.Println(err)
 			return "", err
 		}
 		// Hash and check against the previous hash.
 		hashMap[u] = hex.EncodeToString(s.Sum(nil))
 		if i > 0 && hashMap[u] != prevHash {
 			err = fmt.Errorf("Hash of asset at %s, is not identical to one of the others: %s.", u, prevHash)
-			logErr.Println(err)
 			return "", err
 		}
 		prevHash = hashMap[u]
 	}
 	// Set hash, having successfully hashing each URL, and checking for duplicates.
 	a.hash = hashMap[a.Uris[0]]
+	logger().Debug("hashed asset", "uris", a.Uris, "hash", a.hash)
 
 	return a.hash, nil
 }
@@ -2,8 +2,8 @@ package nft
 
 // Collection holds NFT collection data.
 type Collection struct {
-	Id
-	Nfts    []*NFT
+	Id      string // Collection ID, such as the CHIP-0007 collection UUID.
+	Nfts    []*Nft
 	Fee     uint    // Fee in mojos
 	Royalty float64 // Royalty as a percentage; not a fraction or basis points.
 }
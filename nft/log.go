@@ -1,18 +1,33 @@
 package nft
 
 import (
-	"log"
-	"os"
+	"context"
+	"log/slog"
+	"sync/atomic"
 )
 
-const (
-	logPrefix = "chia/nft: "
+// discardHandler is a slog.Handler which discards every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+var (
+	discardLogger = slog.New(discardHandler{})
+	defaultLogger atomic.Pointer[slog.Logger]
 )
 
-func init() {
-	var (
-		logErr = log.Default()
-		logger = log.New(os.Stdout, logPrefix, log.LstdFlags)
-	)
-	logErr.SetPrefix(logPrefix)
+// SetLogger sets the package's *slog.Logger. The package is silent until this is called; passing nil silences it again.
+func SetLogger(l *slog.Logger) {
+	defaultLogger.Store(l)
+}
+
+// logger returns the package's *slog.Logger.
+func logger() *slog.Logger {
+	if l := defaultLogger.Load(); l != nil {
+		return l
+	}
+	return discardLogger
 }
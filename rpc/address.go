@@ -0,0 +1,149 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// bech32Charset is the alphabet of the bech32 and bech32m encodings.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32mConst is the checksum constant of the bech32m encoding (BIP-350), which Chia uses for addresses and offers.
+const bech32mConst = 0x2bc830a3
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	r := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		r = append(r, hrp[i]>>5)
+	}
+	r = append(r, 0)
+	for i := 0; i < len(hrp); i++ {
+		r = append(r, hrp[i]&31)
+	}
+	return r
+}
+
+// convertBits regroups data from groups of "from" bits to groups of "to" bits. If pad is false, leftover bits must be zero padding.
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc, bits uint
+	maxv := uint(1)<<to - 1
+	r := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, v := range data {
+		if uint(v)>>from != 0 {
+			return nil, fmt.Errorf("Invalid data value %d for %d bit groups.", v, from)
+		}
+		acc = acc<<from | uint(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			r = append(r, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			r = append(r, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, fmt.Errorf("Invalid padding in bech32m data.")
+	}
+	return r, nil
+}
+
+// Bech32mEncode encodes data with the human readable part hrp, in bech32m. Unlike BIP-350, there is no length limit, since Chia offers are far longer than 90 characters.
+func Bech32mEncode(hrp string, data []byte) (string, error) {
+	if hrp == "" {
+		return "", fmt.Errorf("Empty bech32m human readable part.")
+	}
+	hrp = strings.ToLower(hrp)
+	d, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	values := append(bech32HrpExpand(hrp), d...)
+	mod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ bech32mConst
+	var b strings.Builder
+	b.Grow(len(hrp) + 1 + len(d) + 6)
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range d {
+		b.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Charset[(mod>>(5*(5-i)))&31])
+	}
+	return b.String(), nil
+}
+
+// Bech32mDecode decodes a bech32m string, returning its human readable part and data. As with Bech32mEncode, there is no length limit.
+func Bech32mDecode(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("Mixed case bech32m string.")
+	}
+	s = strings.ToLower(s)
+	i := strings.LastIndexByte(s, '1')
+	if i < 1 || i+7 > len(s) {
+		return "", nil, fmt.Errorf("Invalid bech32m separator position.")
+	}
+	hrp := s[:i]
+	for j := 0; j < len(hrp); j++ {
+		if hrp[j] < 33 || hrp[j] > 126 {
+			return "", nil, fmt.Errorf("Invalid character in bech32m human readable part.")
+		}
+	}
+	d := make([]byte, 0, len(s)-i-1)
+	for _, c := range s[i+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return "", nil, fmt.Errorf("Invalid bech32m character %q.", c)
+		}
+		d = append(d, byte(v))
+	}
+	if bech32Polymod(append(bech32HrpExpand(hrp), d...)) != bech32mConst {
+		return "", nil, fmt.Errorf("Invalid bech32m checksum.")
+	}
+	data, err := convertBits(d[:len(d)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+// EncodeAddress returns the address, with the given prefix (such as "xch"), of the puzzle hash ph, given in hex, with or without a 0x prefix.
+func EncodeAddress(prefix, ph string) (string, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(ph, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("Invalid puzzle hash %q. Error: %w", ph, err)
+	}
+	if len(b) != 32 {
+		return "", fmt.Errorf("Invalid puzzle hash %q; expected 32 bytes, got %d.", ph, len(b))
+	}
+	return Bech32mEncode(prefix, b)
+}
+
+// DecodeAddress returns the prefix (such as "xch") of an address, and the puzzle hash it encodes, in hex with a 0x prefix.
+func DecodeAddress(addr string) (string, string, error) {
+	prefix, b, err := Bech32mDecode(addr)
+	if err != nil {
+		return "", "", fmt.Errorf("Invalid address %q. Error: %w", addr, err)
+	}
+	if len(b) != 32 {
+		return "", "", fmt.Errorf("Invalid address %q; expected a 32 byte puzzle hash, got %d bytes.", addr, len(b))
+	}
+	return prefix, "0x" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBech32mVectors(t *testing.T) {
+	// Valid bech32m strings from BIP-350.
+	for _, s := range []string{
+		"A1LQFN3A",
+		"a1lqfn3a",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+		"?1v759aa",
+	} {
+		if _, _, err := Bech32mDecode(s); err != nil {
+			t.Errorf("Bech32mDecode(%q) failed: %s", s, err)
+		}
+	}
+	// A bech32 string, a bad checksum, and mixed case.
+	for _, s := range []string{"A1G7SGD8", "a1lqfn3b", "A1lqfn3a"} {
+		if _, _, err := Bech32mDecode(s); err == nil {
+			t.Errorf("Bech32mDecode(%q) succeeded", s)
+		}
+	}
+}
+
+func TestAddressRoundTrip(t *testing.T) {
+	ph := "0x" + strings.Repeat("ab", 32)
+	a, err := EncodeAddress("txch", ph)
+	if err != nil {
+		t.Fatalf("EncodeAddress failed: %s", err)
+	}
+	if !strings.HasPrefix(a, "txch1") || len(a) != 63 {
+		t.Errorf("Unexpected address %s", a)
+	}
+	p, h, err := DecodeAddress(a)
+	if err != nil {
+		t.Fatalf("DecodeAddress failed: %s", err)
+	}
+	if p != "txch" || h != ph {
+		t.Errorf("Decoded %s, %s", p, h)
+	}
+	if _, err := EncodeAddress("xch", "0xabcd"); err == nil {
+		t.Error("Encoded a short puzzle hash")
+	}
+}
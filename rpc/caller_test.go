@@ -1,19 +1,23 @@
-package rpc
+package rpc_test
 
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+	"github.com/Jsewill/chia/rpc/rpctest"
 )
 
 func TestCallSyncStatus(t *testing.T) {
+	w := rpctest.NewServer(t, rpc.ServiceWallet).Endpoint()
 	// Make request
-	r := &SyncStatusRequest{}
-	out, err := Call(Wallet, r.Procedure().String(), r)
+	r := &rpc.SyncStatusRequest{}
+	out, err := rpc.Call(w, r.Procedure().String(), r)
 	if err != nil {
-		t.Errorf("Sync Status Request failed: %s", err)
+		t.Fatalf("Sync Status Request failed: %s", err)
 	}
 	// Handle response
-	status := new(SyncStatusResponse)
+	status := new(rpc.SyncStatusResponse)
 	err = json.Unmarshal(out, status)
 	if err != nil {
 		t.Errorf("Sync Status unmarshal response failed: %s", err)
@@ -21,18 +25,18 @@ func TestCallSyncStatus(t *testing.T) {
 	if !status.Success {
 		t.Errorf("Sync Status Request was unsuccessful: %s", status.Error)
 	}
-	t.Log(status, err)
 }
 
 func TestCallGenericSyncStatus(t *testing.T) {
+	w := rpctest.NewServer(t, rpc.ServiceWallet).Endpoint()
 	// Make request
-	r := NewUntypedRequest(WalletSyncStatus)
-	out, err := Call(Wallet, r.Procedure().String(), &r)
+	r := rpc.NewUntypedRequest(rpc.WalletSyncStatus)
+	out, err := rpc.Call(w, r.Procedure().String(), &r)
 	if err != nil {
-		t.Errorf("Sync Status Request failed: %s", err)
+		t.Fatalf("Sync Status Request failed: %s", err)
 	}
 	// Handle response
-	status := NewUntypedResponse()
+	status := rpc.NewUntypedResponse()
 	err = json.Unmarshal(out, &status)
 	if err != nil {
 		t.Errorf("Sync Status unmarshal response failed: %s", err)
@@ -41,7 +45,5 @@ func TestCallGenericSyncStatus(t *testing.T) {
 		t.Errorf("Sync Status Request was unsuccessful: %s", status["error"])
 	} else if !ok {
 		t.Errorf("Sync Status Response success not boolean: %s; error: %s", status["success"], status["error"])
-
 	}
-	t.Log(status, err)
 }
@@ -0,0 +1,309 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CATUnitsPerCAT is the number of the smallest units of a CAT in one CAT. CAT amounts in requests and responses are in these units, not mojos; one unit of a CAT is one mojo of XCH wrapped.
+const CATUnitsPerCAT = 1000
+
+// CATAmount is an amount of a CAT, in its smallest units, each 1/CATUnitsPerCAT of a CAT.
+type CATAmount uint
+
+// ParseCATAmount parses a decimal amount of CATs, such as "12.345", with at most three decimal places.
+func ParseCATAmount(s string) (CATAmount, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if len(frac) > 3 {
+		return 0, fmt.Errorf("Invalid CAT amount %q; at most 3 decimal places.", s)
+	}
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("Invalid CAT amount %q.", s)
+	}
+	var w, f uint64
+	var err error
+	if whole != "" {
+		if w, err = strconv.ParseUint(whole, 10, 64); err != nil {
+			return 0, fmt.Errorf("Invalid CAT amount %q. Error: %w", s, err)
+		}
+	}
+	if frac != "" {
+		if f, err = strconv.ParseUint(frac+strings.Repeat("0", 3-len(frac)), 10, 64); err != nil {
+			return 0, fmt.Errorf("Invalid CAT amount %q. Error: %w", s, err)
+		}
+	}
+	if w > (math.MaxUint-f)/CATUnitsPerCAT {
+		return 0, fmt.Errorf("Invalid CAT amount %q; too large.", s)
+	}
+	return CATAmount(w*CATUnitsPerCAT + f), nil
+}
+
+// CATs returns the amount in CATs.
+func (a CATAmount) CATs() float64 {
+	return float64(a) / CATUnitsPerCAT
+}
+
+// String returns the amount in CATs, with three decimal places. Implements the fmt.Stringer interface.
+func (a CATAmount) String() string {
+	return fmt.Sprintf("%d.%03d", a/CATUnitsPerCAT, a%CATUnitsPerCAT)
+}
+
+// ConfirmedCAT returns the confirmed balance of a CAT wallet.
+func (w *WalletBalance) ConfirmedCAT() CATAmount {
+	return CATAmount(w.ConfirmedWalletBalance)
+}
+
+// SpendableCAT returns the spendable balance of a CAT wallet.
+func (w *WalletBalance) SpendableCAT() CATAmount {
+	return CATAmount(w.SpendableBalance)
+}
+
+// UnconfirmedCAT returns the unconfirmed balance of a CAT wallet, including pending transactions.
+func (w *WalletBalance) UnconfirmedCAT() CATAmount {
+	return CATAmount(w.UnconfirmedWalletBalance)
+}
+
+// CATBalance returns the balance of the CAT wallet of the asset with the given ID. If there is no such wallet, the error wraps ErrUnknownWallet.
+func CATBalance(ctx context.Context, c Caller, assetId string) (*WalletBalance, error) {
+	w, err := FindCATWallet(ctx, c, assetId)
+	if err != nil {
+		return nil, err
+	}
+	r, err := (&WalletBalanceRequest{WalletId: w.Id}).SendContext(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get balance of wallet %d. Error: %w", w.Id, err)
+	}
+	return r.WalletBalance, nil
+}
+
+// CATWalletResponse represents the Chia RPC API's response to a CATWalletRequest.
+type CATWalletResponse struct {
+	Type         WalletType           `json:"type"`
+	AssetId      string               `json:"asset_id"`
+	WalletId     uint                 `json:"wallet_id"`
+	Transactions []*TransactionRecord `json:"transactions,omitempty"` // For a new issuance, in recent versions of Chia.
+	Response
+}
+
+// CATWalletRequest is a type for making a request to create a CAT wallet; for a new CAT, issuing Amount units of it, or, with Mode "existing", for an existing asset.
+type CATWalletRequest struct {
+	WalletType string    `json:"wallet_type"` // "cat_wallet"; set by SendContext if empty.
+	Mode       string    `json:"mode"`        // "new" or "existing"; see NewCATRequest and ExistingCATRequest.
+	Name       string    `json:"name,omitempty"`
+	Amount     CATAmount `json:"amount,omitempty"`   // For "new", the amount to issue.
+	AssetId    string    `json:"asset_id,omitempty"` // For "existing".
+	Fee        uint      `json:"fee,omitempty"`      // In mojos.
+}
+
+// NewCATRequest returns a *CATWalletRequest to issue amount of a new CAT, with a single issuance TAIL, named name.
+func NewCATRequest(name string, amount CATAmount, fee uint) *CATWalletRequest {
+	return &CATWalletRequest{WalletType: "cat_wallet", Mode: "new", Name: name, Amount: amount, Fee: fee}
+}
+
+// ExistingCATRequest returns a *CATWalletRequest to add a wallet for the existing CAT with the given asset ID.
+func ExistingCATRequest(assetId string) *CATWalletRequest {
+	return &CATWalletRequest{WalletType: "cat_wallet", Mode: "existing", AssetId: strings.TrimPrefix(assetId, "0x")}
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CATWalletRequest) Procedure() Procedure {
+	return WalletCreateNewWallet
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CATWalletRequest) Send(e *Endpoint) (*CATWalletResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CATWalletRequest) SendContext(ctx context.Context, caller Caller) (*CATWalletResponse, error) {
+	if c.WalletType == "" {
+		// Default a copy, leaving the caller's request as it was.
+		d := *c
+		d.WalletType = "cat_wallet"
+		c = &d
+	}
+	return Do[*CATWalletRequest, CATWalletResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CATWalletRequest) String() string {
+	return requestString(c)
+}
+
+// CATSetNameResponse represents the Chia RPC API's response to a CATSetNameRequest.
+type CATSetNameResponse struct {
+	WalletId uint `json:"wallet_id"`
+	Response
+}
+
+// CATSetNameRequest is a type for making a request to rename a CAT wallet.
+type CATSetNameRequest struct {
+	WalletId uint   `json:"wallet_id"`
+	Name     string `json:"name"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CATSetNameRequest) Procedure() Procedure {
+	return WalletCATSetName
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CATSetNameRequest) Send(e *Endpoint) (*CATSetNameResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CATSetNameRequest) SendContext(ctx context.Context, caller Caller) (*CATSetNameResponse, error) {
+	return Do[*CATSetNameRequest, CATSetNameResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CATSetNameRequest) String() string {
+	return requestString(c)
+}
+
+// CATNameResponse represents the Chia RPC API's response to a CATNameRequest.
+type CATNameResponse struct {
+	WalletId uint   `json:"wallet_id"`
+	Name     string `json:"name"`
+	Response
+}
+
+// CATNameRequest is a type for making a request for the name of a CAT wallet.
+type CATNameRequest struct {
+	WalletId uint `json:"wallet_id"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CATNameRequest) Procedure() Procedure {
+	return WalletCATGetName
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CATNameRequest) Send(e *Endpoint) (*CATNameResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CATNameRequest) SendContext(ctx context.Context, caller Caller) (*CATNameResponse, error) {
+	return Do[*CATNameRequest, CATNameResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CATNameRequest) String() string {
+	return requestString(c)
+}
+
+// CATAssetIdResponse represents the Chia RPC API's response to a CATAssetIdRequest.
+type CATAssetIdResponse struct {
+	AssetId  string `json:"asset_id"` // In hex, without a 0x prefix.
+	WalletId uint   `json:"wallet_id"`
+	Response
+}
+
+// CATAssetIdRequest is a type for making a request for the asset ID of a CAT wallet.
+type CATAssetIdRequest struct {
+	WalletId uint `json:"wallet_id"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CATAssetIdRequest) Procedure() Procedure {
+	return WalletCATGetAssetId
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CATAssetIdRequest) Send(e *Endpoint) (*CATAssetIdResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CATAssetIdRequest) SendContext(ctx context.Context, caller Caller) (*CATAssetIdResponse, error) {
+	return Do[*CATAssetIdRequest, CATAssetIdResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CATAssetIdRequest) String() string {
+	return requestString(c)
+}
+
+// CATAddition is a payment of a CATSpendRequest with several recipients.
+type CATAddition struct {
+	Amount     CATAmount `json:"amount"`
+	PuzzleHash string    `json:"puzzle_hash"`
+	Memos      []string  `json:"memos,omitempty"`
+}
+
+// CATSpendRequest is a type for making a request to send a CAT; Amount to InnerAddress, or, if Additions are set, to each of them. Fee is in mojos of XCH.
+type CATSpendRequest struct {
+	WalletId           uint           `json:"wallet_id"`
+	InnerAddress       string         `json:"inner_address,omitempty"`
+	Amount             CATAmount      `json:"amount,omitempty"`
+	Additions          []*CATAddition `json:"additions,omitempty"`
+	Fee                uint           `json:"fee"`
+	Memos              []string       `json:"memos,omitempty"`
+	MinCoinAmount      CATAmount      `json:"min_coin_amount,omitempty"`
+	MaxCoinAmount      CATAmount      `json:"max_coin_amount,omitempty"`
+	ExcludeCoinAmounts []CATAmount    `json:"exclude_coin_amounts,omitempty"`
+	ExcludeCoinIds     []string       `json:"exclude_coin_ids,omitempty"`
+	ReusePuzHash       bool           `json:"reuse_puzhash,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CATSpendRequest) Procedure() Procedure {
+	return WalletCATSpend
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CATSpendRequest) Send(e *Endpoint) (*SendTransactionResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CATSpendRequest) SendContext(ctx context.Context, caller Caller) (*SendTransactionResponse, error) {
+	return Do[*CATSpendRequest, SendTransactionResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CATSpendRequest) String() string {
+	return requestString(c)
+}
+
+// KnownCAT is a CAT known to the wallet service by default.
+type KnownCAT struct {
+	AssetId string `json:"asset_id"`
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol"`
+}
+
+// CATListResponse represents the Chia RPC API's response to a CATListRequest.
+type CATListResponse struct {
+	CATList []*KnownCAT `json:"cat_list"`
+	Response
+}
+
+// CATListRequest is a type for making a request for the CATs known to the wallet service by default.
+type CATListRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CATListRequest) Procedure() Procedure {
+	return WalletGetCATList
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CATListRequest) Send(e *Endpoint) (*CATListResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CATListRequest) SendContext(ctx context.Context, caller Caller) (*CATListResponse, error) {
+	return Do[*CATListRequest, CATListResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CATListRequest) String() string {
+	return requestString(c)
+}
@@ -0,0 +1,64 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+	"github.com/Jsewill/chia/rpc/rpctest"
+)
+
+func TestCATAmount(t *testing.T) {
+	for s, want := range map[string]rpc.CATAmount{"12.345": 12345, "1": 1000, ".5": 500, "0.01": 10, "7.": 7000} {
+		if a, err := rpc.ParseCATAmount(s); err != nil || a != want {
+			t.Errorf("ParseCATAmount(%q) = %d, %v, want %d", s, a, err, want)
+		}
+	}
+	for _, s := range []string{"", ".", "1.2345", "-1", "1e3", "18446744073709552"} {
+		if a, err := rpc.ParseCATAmount(s); err == nil {
+			t.Errorf("ParseCATAmount(%q) = %d, expected an error", s, a)
+		}
+	}
+	if s := rpc.CATAmount(12005).String(); s != "12.005" {
+		t.Errorf("Got %s, want 12.005", s)
+	}
+}
+
+func TestCATWallet(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	w := s.Endpoint()
+	ctx := context.Background()
+	amount, _ := rpc.ParseCATAmount("1000")
+	r, err := rpc.NewCATRequest("Spacebucks", amount, 100000).SendContext(ctx, w)
+	if err != nil || r.Type != rpc.CATWallet || r.AssetId != rpctest.FixtureAssetId {
+		t.Fatalf("Unexpected new CAT wallet: %+v, %v", r, err)
+	}
+	if _, err := rpc.ExistingCATRequest("0x"+rpctest.FixtureAssetId).SendContext(ctx, w); err != nil {
+		t.Fatalf("Existing CAT wallet request failed: %s", err)
+	}
+	// The wallet type is defaulted in the call, not in the request.
+	req := &rpc.CATWalletRequest{Mode: "existing", AssetId: rpctest.FixtureAssetId}
+	if _, err := req.SendContext(ctx, w); err != nil || req.WalletType != "" {
+		t.Fatalf("Request changed to %+v, %v", req, err)
+	}
+	cs := s.CallsTo(rpc.WalletCreateNewWallet)
+	if len(cs) != 3 || string(cs[2].Request) != string(cs[1].Request) || string(cs[0].Request) != `{"wallet_type":"cat_wallet","mode":"new","name":"Spacebucks","amount":1000000,"fee":100000}` ||
+		string(cs[1].Request) != `{"wallet_type":"cat_wallet","mode":"existing","asset_id":"`+rpctest.FixtureAssetId+`"}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	sr, err := (&rpc.CATSpendRequest{WalletId: 2, InnerAddress: "xch1f0ryxk6qn096hefcwrdwpuph2hm24w69jnzezhkfswk0z2jar7aq5zzpfj", Amount: 2500}).SendContext(ctx, w)
+	if err != nil || sr.TransactionId != rpctest.FixtureTxId {
+		t.Errorf("Unexpected CAT spend: %+v, %v", sr, err)
+	}
+	b, err := rpc.CATBalance(ctx, w, rpctest.FixtureAssetId)
+	if err != nil || b.SpendableCAT().String() != "1000000000.000" {
+		t.Errorf("Unexpected CAT balance: %+v, %v", b, err)
+	}
+	if cs := s.CallsTo(rpc.WalletGetBalance); len(cs) != 1 || string(cs[0].Request) != `{"wallet_id":2}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	l, err := new(rpc.CATListRequest).SendContext(ctx, w)
+	if err != nil || len(l.CATList) != 1 || l.CATList[0].Symbol != "SBX" {
+		t.Errorf("Unexpected CAT list: %+v, %v", l, err)
+	}
+}
@@ -2,9 +2,10 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 )
 
@@ -13,34 +14,39 @@ const (
 )
 
 var (
-	DefaultPath     = ".chia"
-	DefaultCertPath = "mainnet/config/ssl/"
-	HomeDir         = ""
+	DefaultPath     = ".chia"               // Path of the Chia directory, relative to the home directory.
+	DefaultCertPath = "mainnet/config/ssl/" // Path of the Chia certificate directory, relative to DefaultPath.
+	HomeDir         = ""                    // Home directory override. If empty, the user's home directory is used.
 )
 
-func init() {
-	HomeDir, err := os.UserHomeDir()
-	if err != nil {
-		logErr.Panicf("Couldn't get home directory path. Error: %s", err)
+// homeDir returns HomeDir if set, otherwise the user's home directory.
+func homeDir() (string, error) {
+	if HomeDir != "" {
+		return HomeDir, nil
 	}
-	if _, err := os.Stat(HomeDir); os.IsNotExist(err) {
-		logErr.Panicln("System specified home directory does not exist.")
+	h, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("Couldn't get home directory path. Error: %w", err)
 	}
-	DefaultPath = filepath.Join(HomeDir, DefaultPath)
+	return h, nil
 }
 
 // Call is a general endpoint call function for convenience.
 // It takes a Caller, procedure name string, and an any data type to marshal into JSON.
 // @TODO: Expand definition for use with interfaces. May require some renaming of Endpoint and Procedure functions as well as package interfaces rework.
 func Call(c Caller, p string, d interface{}) ([]byte, error) {
+	return CallContext(context.Background(), c, p, d)
+}
+
+// CallContext is like Call, but with a Context.
+func CallContext(ctx context.Context, c Caller, p string, d interface{}) ([]byte, error) {
 	// Marshal request body as JSON
 	j, err := json.Marshal(d)
 	if err != nil {
-		logErr.Println(err)
 		return nil, err
 	}
 	// Make POST request
-	return c.Call(Procedure(p), j)
+	return c.CallContext(ctx, Procedure(p), j)
 }
 
 // Errors is a slice of error, and itself implements the built-in error interface.
@@ -0,0 +1,154 @@
+package rpc
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ClientOption configures a Client. See NewClient.
+type ClientOption func(*Client) error
+
+// WithRoot sets the Chia root directory from which the Client loads its config. By default DefaultRoot is used.
+func WithRoot(r string) ClientOption {
+	return func(c *Client) error {
+		c.root = r
+		return nil
+	}
+}
+
+// WithConfig sets the Chia config the Client uses, rather than loading one from the root directory.
+func WithConfig(cfg *Config) ClientOption {
+	return func(c *Client) error {
+		c.config = cfg
+		return nil
+	}
+}
+
+// WithEndpoint sets the Endpoint the Client uses for the service named by e.Name, rather than one built from the config.
+func WithEndpoint(e *Endpoint) ClientOption {
+	return func(c *Client) error {
+		c.endpoints[e.Name] = e
+		return nil
+	}
+}
+
+// WithNetwork sets the Network the Client's services are expected to be on. Unless WithRoot is given, the config is loaded from the network's root directory, and NewClient returns an error wrapping ErrWrongNetwork if the config selects another network.
+// By default, the network selected by the config is used. See Config.Network.
+func WithNetwork(n *Network) ClientOption {
+	return func(c *Client) error {
+		c.network = n
+		return nil
+	}
+}
+
+// WithLogger sets the *slog.Logger given to the Endpoints the Client builds from the config.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.logger = l
+		return nil
+	}
+}
+
+// A Client provides the Endpoints of the Chia services described by a Chia config. Each Endpoint is initialized on its first use, so a Client can be created on machines which only run some of the services.
+type Client struct {
+	root      string
+	config    *Config
+	network   *Network
+	logger    *slog.Logger
+	mu        sync.Mutex
+	endpoints map[string]*Endpoint
+}
+
+// NewClient returns a new *Client configured by opts. Unless WithConfig is given, the Chia config is loaded from the root directory, and an error is returned if that fails.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	c := &Client{endpoints: make(map[string]*Endpoint)}
+	for _, o := range opts {
+		if err := o(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.root == "" && c.network != nil {
+		r, err := c.network.Root()
+		if err != nil {
+			return nil, err
+		}
+		c.root = r
+	}
+	if c.config == nil {
+		cfg, err := LoadConfig(c.root)
+		if err != nil {
+			return nil, err
+		}
+		c.config = cfg
+	}
+	if c.network == nil {
+		c.network = c.config.Network()
+	} else if s := c.config.SelectedNetwork; s != "" && s != c.network.Name {
+		return nil, fmt.Errorf("Chia config selects network %q, not %q: %w", s, c.network.Name, ErrWrongNetwork)
+	}
+	for _, e := range c.endpoints {
+		if e.Network == nil {
+			e.Network = c.network
+		}
+	}
+	return c, nil
+}
+
+// Config returns the Chia config used by the Client.
+func (c *Client) Config() *Config {
+	return c.config
+}
+
+// Network returns the Network of the Client's services.
+func (c *Client) Network() *Network {
+	return c.network
+}
+
+// Endpoint returns the initialized *Endpoint for the named service, creating and initializing it on first use.
+func (c *Client) Endpoint(name string) (*Endpoint, error) {
+	c.mu.Lock()
+	e, ok := c.endpoints[name]
+	if !ok {
+		var err error
+		e, err = c.config.endpoint(name)
+		if err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		// The Client's network may be given by WithNetwork, rather than the config.
+		e.Network = c.network
+		e.Logger = c.logger
+		c.endpoints[name] = e
+	}
+	c.mu.Unlock()
+	if err := e.ensureInit(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Wallet returns the wallet service *Endpoint.
+func (c *Client) Wallet() (*Endpoint, error) {
+	return c.Endpoint(ServiceWallet)
+}
+
+// FullNode returns the full node service *Endpoint.
+func (c *Client) FullNode() (*Endpoint, error) {
+	return c.Endpoint(ServiceFullNode)
+}
+
+// Farmer returns the farmer service *Endpoint.
+func (c *Client) Farmer() (*Endpoint, error) {
+	return c.Endpoint(ServiceFarmer)
+}
+
+// Harvester returns the harvester service *Endpoint.
+func (c *Client) Harvester() (*Endpoint, error) {
+	return c.Endpoint(ServiceHarvester)
+}
+
+// Daemon returns the daemon service *Endpoint.
+func (c *Client) Daemon() (*Endpoint, error) {
+	return c.Endpoint(ServiceDaemon)
+}
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"testing"
+)
+
+func TestClientLazyEndpoint(t *testing.T) {
+	cfg, err := ParseConfig(testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Root = t.TempDir()
+	c, err := NewClient(WithConfig(cfg))
+	if err != nil {
+		t.Fatalf("NewClient failed: %s", err)
+	}
+	// There are no certificates under the root, so initialization must fail with an error, rather than a panic.
+	if _, err := c.FullNode(); err == nil {
+		t.Error("Expected an error initializing the full node endpoint without certificates")
+	}
+	if _, err := c.Harvester(); err == nil {
+		t.Error("Expected an error for a service missing from the config")
+	}
+}
+
+func TestNewClientMissingConfig(t *testing.T) {
+	if _, err := NewClient(WithRoot(t.TempDir())); err == nil {
+		t.Error("Expected an error for a root without a config")
+	}
+}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// DefaultCoinRecordWindow is the number of heights a CoinRecordPager queries at once, unless its Window is set. It is about a week of blocks.
+var DefaultCoinRecordWindow uint = 32 * 1024
+
+// A CoinRecordPager walks the CoinRecords of puzzle hashes, confirmed from StartHeight up to but excluding EndHeight, one window of heights at a time, so that heavily used puzzle hashes don't time out the node, or fill memory.
+// If a window times out, it is halved and retried, down to a single height.
+type CoinRecordPager struct {
+	PuzzleHashes []string
+	StartHeight  uint
+	EndHeight    uint // If zero, the height after the node's peak, when the walk starts.
+	IncludeSpent bool
+	Window       uint // Number of heights per request. If zero, DefaultCoinRecordWindow is used.
+}
+
+// Pages returns an iterator over the CoinRecords of each window of heights, in order of height, window by window in order of height, queried via c. Windows without records are skipped. Iteration stops after the first error.
+func (p *CoinRecordPager) Pages(ctx context.Context, c Caller) iter.Seq2[[]*CoinRecord, error] {
+	return func(yield func([]*CoinRecord, error) bool) {
+		if len(p.PuzzleHashes) == 0 {
+			yield(nil, fmt.Errorf("Failed to page coin records, please set PuzzleHashes."))
+			return
+		}
+		end := p.EndHeight
+		if end == 0 {
+			r, err := new(BlockchainStateRequest).SendContext(ctx, c)
+			if err != nil {
+				yield(nil, fmt.Errorf("Couldn't get peak height for paging coin records. Error: %w", err))
+				return
+			}
+			if r.BlockchainState == nil || r.BlockchainState.Peak == nil {
+				yield(nil, fmt.Errorf("Couldn't get peak height for paging coin records; the node has no peak."))
+				return
+			}
+			end = r.BlockchainState.Peak.Height + 1
+		}
+		w := p.Window
+		if w == 0 {
+			w = DefaultCoinRecordWindow
+		}
+		for h := p.StartHeight; h < end; {
+			n := min(w, end-h)
+			req := &CoinRecordsByPuzzleHashesRequest{PuzzleHashes: p.PuzzleHashes, StartHeight: h, EndHeight: h + n, IncludeSpent: p.IncludeSpent}
+			r, err := req.SendContext(ctx, c)
+			if err != nil {
+				// Shrink the window if the node took too long, unless the caller has given up.
+				if n > 1 && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+					w = n / 2
+					continue
+				}
+				yield(nil, fmt.Errorf("Couldn't get coin records from height %d. Error: %w", h, err))
+				return
+			}
+			h += n
+			if len(r.CoinRecords) == 0 {
+				continue
+			}
+			if !yield(r.CoinRecords, nil) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over all the CoinRecords of the pager's puzzle hashes, queried via c. See Pages.
+func (p *CoinRecordPager) All(ctx context.Context, c Caller) iter.Seq2[*CoinRecord, error] {
+	return func(yield func(*CoinRecord, error) bool) {
+		for rs, err := range p.Pages(ctx, c) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, r := range rs {
+				if !yield(r, nil) {
+					return
+				}
+			}
+		}
+	}
+}
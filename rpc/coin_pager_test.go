@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// heightsCaller answers coin record requests with one record per height in the requested range, failing requests for more than max heights with a timeout.
+type heightsCaller struct {
+	max      uint
+	requests []CoinRecordsByPuzzleHashesRequest
+}
+
+func (h *heightsCaller) CallContext(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+	if p == FullNodeGetBlockchainState {
+		return []byte(`{"success": true, "blockchain_state": {"peak": {"height": 99}}}`), nil
+	}
+	var req CoinRecordsByPuzzleHashesRequest
+	if err := json.Unmarshal(j, &req); err != nil {
+		return nil, err
+	}
+	h.requests = append(h.requests, req)
+	if req.EndHeight-req.StartHeight > h.max {
+		return nil, fmt.Errorf("Error with POST request: %w", context.DeadlineExceeded)
+	}
+	r := &CoinRecordsResponse{Response: Response{Success: true}}
+	for i := req.StartHeight; i < req.EndHeight; i++ {
+		r.CoinRecords = append(r.CoinRecords, &CoinRecord{ConfirmedBlockIndex: i})
+	}
+	return json.Marshal(r)
+}
+
+func TestCoinRecordPager(t *testing.T) {
+	c := &heightsCaller{max: 100}
+	p := &CoinRecordPager{PuzzleHashes: []string{"0x01"}, StartHeight: 10, Window: 30}
+	var heights []uint
+	for r, err := range p.All(context.Background(), c) {
+		if err != nil {
+			t.Fatalf("Paging failed: %s", err)
+		}
+		heights = append(heights, r.ConfirmedBlockIndex)
+	}
+	// Heights 10 to the peak, 99, in windows of 30.
+	if len(heights) != 90 || heights[0] != 10 || heights[89] != 99 {
+		t.Errorf("Unexpected heights %v", heights)
+	}
+	if len(c.requests) != 3 || c.requests[2].StartHeight != 70 || c.requests[2].EndHeight != 100 {
+		t.Errorf("Unexpected requests %+v", c.requests)
+	}
+}
+
+func TestCoinRecordPagerShrinks(t *testing.T) {
+	c := &heightsCaller{max: 10}
+	p := &CoinRecordPager{PuzzleHashes: []string{"0x01"}, EndHeight: 40, Window: 40}
+	n := 0
+	for rs, err := range p.Pages(context.Background(), c) {
+		if err != nil {
+			t.Fatalf("Paging failed: %s", err)
+		}
+		n += len(rs)
+	}
+	if n != 40 {
+		t.Errorf("Got %d records, expected 40", n)
+	}
+	// 40 and 20 time out, then 10 at a time.
+	if len(c.requests) != 6 {
+		t.Errorf("Unexpected requests %+v", c.requests)
+	}
+	// Stopping early makes no more requests.
+	c.requests = nil
+	for range p.All(context.Background(), c) {
+		break
+	}
+	if len(c.requests) != 3 {
+		t.Errorf("Unexpected requests after stopping %+v", c.requests)
+	}
+}
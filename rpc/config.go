@@ -0,0 +1,243 @@
+package rpc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RootEnv is the environment variable Chia uses to locate its root directory.
+	RootEnv = "CHIA_ROOT"
+	// ConfigFile is the path of the Chia config file, relative to the root directory.
+	ConfigFile = "config/config.yaml"
+)
+
+// Service names, as used by Chia for config sections and certificate file names.
+const (
+	ServiceDaemon    = "daemon"
+	ServiceFarmer    = "farmer"
+	ServiceFullNode  = "full_node"
+	ServiceHarvester = "harvester"
+	ServiceWallet    = "wallet"
+)
+
+// defaultPorts are the RPC ports of a stock Chia install, keyed by service name.
+var defaultPorts = map[string]uint{
+	ServiceDaemon:    55400,
+	ServiceFarmer:    8559,
+	ServiceFullNode:  8555,
+	ServiceHarvester: 8560,
+	ServiceWallet:    9256,
+}
+
+// DefaultRoot returns the Chia root directory; the value of CHIA_ROOT if set, otherwise the mainnet directory under DefaultPath in the user's home directory. See Network.Root.
+func DefaultRoot() (string, error) {
+	return Mainnet.Root()
+}
+
+// expandHome replaces a leading "~" in p with the user's home directory.
+func expandHome(p string) (string, error) {
+	if p != "~" && (len(p) < 2 || p[:2] != "~/") {
+		return p, nil
+	}
+	h, err := homeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(h, p[1:]), nil
+}
+
+// SSLConfig holds a certificate and key path pair from the Chia config.
+type SSLConfig struct {
+	Crt        string `yaml:"crt"`
+	Key        string `yaml:"key"`
+	PrivateCrt string `yaml:"private_crt"`
+	PrivateKey string `yaml:"private_key"`
+}
+
+// ServiceConfig holds the settings of a single Chia service which are needed to reach its RPC endpoint.
+type ServiceConfig struct {
+	SelfHostname string     `yaml:"self_hostname"`
+	RpcPort      uint       `yaml:"rpc_port"`
+	PrivateSSLCA *SSLConfig `yaml:"private_ssl_ca"`
+	SSL          *SSLConfig `yaml:"ssl"`
+}
+
+// NetworkOverrides holds the per-network settings of the Chia config, keyed by network name. Only those needed to describe a Network are kept.
+type NetworkOverrides struct {
+	Config map[string]struct {
+		AddressPrefix string `yaml:"address_prefix"`
+	} `yaml:"config"`
+	Constants map[string]struct {
+		GenesisChallenge string `yaml:"GENESIS_CHALLENGE"`
+	} `yaml:"constants"`
+}
+
+// Config represents the parts of a Chia config.yaml which concern RPC endpoints.
+type Config struct {
+	Root             string            `yaml:"-"`
+	SelfHostname     string            `yaml:"self_hostname"`
+	SelectedNetwork  string            `yaml:"selected_network"`
+	NetworkOverrides *NetworkOverrides `yaml:"network_overrides"`
+	DaemonPort       uint              `yaml:"daemon_port"`
+	DaemonSSL        *SSLConfig        `yaml:"daemon_ssl"`
+	PrivateSSLCA     *SSLConfig        `yaml:"private_ssl_ca"`
+	FullNode         *ServiceConfig    `yaml:"full_node"`
+	Wallet           *ServiceConfig    `yaml:"wallet"`
+	Farmer           *ServiceConfig    `yaml:"farmer"`
+	Harvester        *ServiceConfig    `yaml:"harvester"`
+}
+
+// LoadConfig reads and parses config.yaml from the Chia root directory, r. If r is empty, DefaultRoot is used.
+func LoadConfig(r string) (*Config, error) {
+	if r == "" {
+		var err error
+		if r, err = DefaultRoot(); err != nil {
+			return nil, err
+		}
+	}
+	r, err := expandHome(r)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(filepath.Join(r, ConfigFile))
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read Chia config. Error: %w", err)
+	}
+	c, err := ParseConfig(b)
+	if err != nil {
+		return nil, err
+	}
+	c.Root = r
+	return c, nil
+}
+
+// ParseConfig parses the YAML contents of a Chia config file. The returned Config has no Root set.
+func ParseConfig(b []byte) (*Config, error) {
+	c := new(Config)
+	if err := yaml.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("Couldn't parse Chia config. Error: %w", err)
+	}
+	return c, nil
+}
+
+// Service returns the ServiceConfig for the named service. The daemon has no section of its own, so one is assembled from the top-level settings.
+func (c *Config) Service(name string) (*ServiceConfig, error) {
+	var s *ServiceConfig
+	switch name {
+	case ServiceDaemon:
+		s = &ServiceConfig{RpcPort: c.DaemonPort, SSL: c.DaemonSSL}
+	case ServiceFullNode:
+		s = c.FullNode
+	case ServiceWallet:
+		s = c.Wallet
+	case ServiceFarmer:
+		s = c.Farmer
+	case ServiceHarvester:
+		s = c.Harvester
+	default:
+		return nil, fmt.Errorf("Unknown Chia service %q.", name)
+	}
+	if s == nil {
+		return nil, fmt.Errorf("Chia config has no %q section.", name)
+	}
+	return s, nil
+}
+
+// Endpoint returns a new, initialized *Endpoint for the named service, using the host, port and certificate paths declared in the config.
+func (c *Config) Endpoint(name string) (*Endpoint, error) {
+	e, err := c.endpoint(name)
+	if err != nil {
+		return nil, err
+	}
+	return e, e.Init()
+}
+
+// Endpoints returns a new, initialized *Endpoint for each of the services declared in the config, keyed by service name.
+func (c *Config) Endpoints() (map[string]*Endpoint, error) {
+	es := make(map[string]*Endpoint)
+	for _, n := range []string{ServiceDaemon, ServiceFullNode, ServiceWallet, ServiceFarmer, ServiceHarvester} {
+		if _, err := c.Service(n); err != nil {
+			continue
+		}
+		e, err := c.Endpoint(n)
+		if err != nil {
+			return nil, err
+		}
+		es[n] = e
+	}
+	return es, nil
+}
+
+// endpoint builds an uninitialized *Endpoint for the named service.
+func (c *Config) endpoint(name string) (*Endpoint, error) {
+	s, err := c.Service(name)
+	if err != nil {
+		return nil, err
+	}
+	e := &Endpoint{Name: name, Host: s.SelfHostname, Port: s.RpcPort, Network: c.Network()}
+	if e.Host == "" {
+		e.Host = c.SelfHostname
+	}
+	if e.Host == "" {
+		e.Host = defaultHost
+	}
+	if e.Port == 0 {
+		return nil, fmt.Errorf("Chia config has no rpc_port for %q.", name)
+	}
+	// Services may declare their own CA, otherwise use the global one.
+	ca := s.PrivateSSLCA
+	if ca == nil {
+		ca = c.PrivateSSLCA
+	}
+	if ca != nil && ca.Crt != "" {
+		e.CACertPath = c.path(ca.Crt)
+	}
+	if s.SSL != nil {
+		if s.SSL.PrivateCrt != "" {
+			e.CertPath = c.path(s.SSL.PrivateCrt)
+		}
+		if s.SSL.PrivateKey != "" {
+			e.KeyPath = c.path(s.SSL.PrivateKey)
+		}
+	}
+	return e, nil
+}
+
+// Network returns the Network selected by the config. Known networks are returned as they are, unless the config overrides their address prefix or genesis challenge, in which case a modified copy is returned. If no network is selected, Mainnet is returned.
+func (c *Config) Network() *Network {
+	name := c.SelectedNetwork
+	if name == "" {
+		return Mainnet
+	}
+	n, ok := LookupNetwork(name)
+	if !ok {
+		n = &Network{Name: name}
+	}
+	if c.NetworkOverrides == nil {
+		return n
+	}
+	m := *n
+	if o, ok := c.NetworkOverrides.Config[name]; ok && o.AddressPrefix != "" {
+		m.AddressPrefix = o.AddressPrefix
+	}
+	if o, ok := c.NetworkOverrides.Constants[name]; ok && o.GenesisChallenge != "" {
+		m.GenesisChallenge = strings.TrimPrefix(o.GenesisChallenge, "0x")
+	}
+	if ok && m.AddressPrefix == n.AddressPrefix && m.GenesisChallenge == n.GenesisChallenge {
+		return n
+	}
+	return &m
+}
+
+// path resolves p relative to the config's root directory.
+func (c *Config) path(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(c.Root, p)
+}
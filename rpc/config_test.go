@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testConfig = []byte(`
+self_hostname: &self_hostname "127.0.0.1"
+selected_network: &selected_network "mainnet"
+daemon_port: 55401
+daemon_ssl:
+  private_crt: config/ssl/daemon/private_daemon.crt
+  private_key: config/ssl/daemon/private_daemon.key
+private_ssl_ca:
+  crt: config/ssl/ca/private_ca.crt
+  key: config/ssl/ca/private_ca.key
+full_node:
+  self_hostname: *self_hostname
+  rpc_port: 18555
+  ssl:
+    private_crt: config/ssl/full_node/private_full_node.crt
+    private_key: config/ssl/full_node/private_full_node.key
+wallet:
+  rpc_port: 19256
+  ssl:
+    private_crt: /elsewhere/private_wallet.crt
+    private_key: /elsewhere/private_wallet.key
+`)
+
+func TestLoadConfig(t *testing.T) {
+	r := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(r, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(r, ConfigFile), testConfig, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(RootEnv, r)
+	c, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %s", err)
+	}
+	if c.Root != r {
+		t.Errorf("Root is %q, expected %q", c.Root, r)
+	}
+
+	e, err := c.endpoint(ServiceFullNode)
+	if err != nil {
+		t.Fatalf("Full node endpoint failed: %s", err)
+	}
+	if e.Host != "127.0.0.1" || e.Port != 18555 {
+		t.Errorf("Full node endpoint is %s", e)
+	}
+	if want := filepath.Join(r, "config/ssl/ca/private_ca.crt"); e.CACertPath != want {
+		t.Errorf("CA path is %q, expected %q", e.CACertPath, want)
+	}
+	if want := filepath.Join(r, "config/ssl/full_node/private_full_node.key"); e.KeyPath != want {
+		t.Errorf("Key path is %q, expected %q", e.KeyPath, want)
+	}
+
+	e, err = c.endpoint(ServiceWallet)
+	if err != nil {
+		t.Fatalf("Wallet endpoint failed: %s", err)
+	}
+	if e.Host != "127.0.0.1" || e.Port != 19256 || e.CertPath != "/elsewhere/private_wallet.crt" {
+		t.Errorf("Wallet endpoint is %s, cert %q", e, e.CertPath)
+	}
+
+	e, err = c.endpoint(ServiceDaemon)
+	if err != nil {
+		t.Fatalf("Daemon endpoint failed: %s", err)
+	}
+	if e.Port != 55401 {
+		t.Errorf("Daemon endpoint is %s", e)
+	}
+
+	if _, err := c.endpoint(ServiceHarvester); err == nil {
+		t.Error("Expected an error for a missing harvester section")
+	}
+}
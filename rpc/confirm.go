@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// decodeHex decodes h, with or without a 0x prefix.
+func decodeHex(h string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(h, "0x"), "0X"))
+}
+
+// Bytes returns the streamable serialization of the spend bundle, as Chia hashes it for its name.
+func (s *SpendBundle) Bytes() ([]byte, error) {
+	b := binary.BigEndian.AppendUint32(nil, uint32(len(s.CoinSolutions)))
+	for i, cs := range s.CoinSolutions {
+		if cs == nil || cs.Coin == nil {
+			return nil, fmt.Errorf("Coin spend %d has no coin.", i)
+		}
+		parent, err := decodeHex(cs.Coin.ParentCoinInfo)
+		if err != nil || len(parent) != 32 {
+			return nil, fmt.Errorf("Invalid parent coin info %q.", cs.Coin.ParentCoinInfo)
+		}
+		ph, err := decodeHex(cs.Coin.PuzzleHash)
+		if err != nil || len(ph) != 32 {
+			return nil, fmt.Errorf("Invalid puzzle hash %q.", cs.Coin.PuzzleHash)
+		}
+		puzzle, err := decodeHex(cs.PuzzleReveal)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid puzzle reveal of coin spend %d. Error: %w", i, err)
+		}
+		solution, err := decodeHex(cs.Solution)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid solution of coin spend %d. Error: %w", i, err)
+		}
+		b = append(b, parent...)
+		b = append(b, ph...)
+		b = binary.BigEndian.AppendUint64(b, uint64(cs.Coin.Amount))
+		// Serialized programs are written as they are, since CLVM serialization is self-delimiting.
+		b = append(b, puzzle...)
+		b = append(b, solution...)
+	}
+	sig, err := decodeHex(s.AggregatedSignature)
+	if err != nil || len(sig) != 96 {
+		return nil, fmt.Errorf("Invalid aggregated signature %q.", s.AggregatedSignature)
+	}
+	return append(b, sig...), nil
+}
+
+// Name returns the name of the spend bundle, in hex with a 0x prefix; the transaction ID by which the mempool knows it.
+func (s *SpendBundle) Name() (string, error) {
+	b, err := s.Bytes()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return "0x" + hex.EncodeToString(h[:]), nil
+}
+
+// RemovalIds returns the IDs of the coins the spend bundle spends.
+func (s *SpendBundle) RemovalIds() ([]string, error) {
+	ids := make([]string, 0, len(s.CoinSolutions))
+	for i, cs := range s.CoinSolutions {
+		if cs == nil || cs.Coin == nil {
+			return nil, fmt.Errorf("Coin spend %d has no coin.", i)
+		}
+		id, err := cs.Coin.ID()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// TxStatus is the outcome of tracking a transaction with a TxTracker.
+type TxStatus int
+
+const (
+	TxPending     TxStatus = iota // Not yet settled.
+	TxConfirmed                   // Spent by the transaction, with the wanted confirmations.
+	TxDropped                     // Gone from the mempool, and its coins unspent.
+	TxDoubleSpent                 // At least one of its coins was spent by another transaction.
+	TxTimedOut                    // Not settled before the tracker's Timeout.
+)
+
+// String implements the fmt.Stringer interface.
+func (s TxStatus) String() string {
+	switch s {
+	case TxPending:
+		return "pending"
+	case TxConfirmed:
+		return "confirmed"
+	case TxDropped:
+		return "dropped"
+	case TxDoubleSpent:
+		return "double spent"
+	case TxTimedOut:
+		return "timed out"
+	}
+	return fmt.Sprintf("TxStatus(%d)", int(s))
+}
+
+// TxResult is the result of tracking a transaction with a TxTracker.
+type TxResult struct {
+	Status        TxStatus
+	TxId          string   // Name of the spend bundle.
+	PushStatus    string   // Status returned by push_tx, such as "SUCCESS" or "PENDING". Empty if it wasn't pushed, or the push failed.
+	Removals      []string // IDs of the coins spent.
+	Additions     []string // IDs of the coins created, once seen in the mempool. Computing them from the bundle would require running its puzzles.
+	Height        uint     // Height of the block which spent the coins, if they were spent.
+	Confirmations uint     // Number of blocks, including its own, confirming the spend.
+	DoubleSpent   []string // For TxDoubleSpent, the IDs of the coins spent by another transaction.
+}
+
+// A TxTracker pushes spend bundles to a full node, and follows them, by polling, until they are confirmed, dropped from the mempool, double spent, or time out.
+type TxTracker struct {
+	Caller        Caller        // The full node.
+	Confirmations uint          // Number of confirmations, including the block itself, to wait for. If zero, one is used.
+	PollInterval  time.Duration // Wait between polls. If zero, DefaultPollInterval is used.
+	Timeout       time.Duration // Time after which tracking ends with TxTimedOut. If zero, tracking continues until settled or the context is done.
+	Logger        *slog.Logger  // Logger for failed polls. If nil, the package's default is used; see SetLogger.
+}
+
+// NewTxTracker returns a new *TxTracker of the full node c, waiting for one confirmation.
+func NewTxTracker(c Caller) *TxTracker {
+	return &TxTracker{Caller: c}
+}
+
+// Track pushes the spend bundle sb, and waits for it to settle, as Wait does. If the push fails, but the bundle is already in the mempool or its coins spent, such as when pushing again after a restart, it is still tracked; otherwise the push error is returned.
+func (t *TxTracker) Track(ctx context.Context, sb *SpendBundle) (*TxResult, error) {
+	r, err := t.newResult(sb)
+	if err != nil {
+		return nil, err
+	}
+	pr, pushErr := (&PushTxRequest{SpendBundle: sb}).SendContext(ctx, t.Caller)
+	if pushErr == nil {
+		r.PushStatus = pr.Status
+		return t.wait(ctx, sb, r)
+	}
+	if _, ok := AsAPIError(pushErr); !ok {
+		return r, fmt.Errorf("Couldn't push transaction %s. Error: %w", r.TxId, pushErr)
+	}
+	settled, err := t.poll(ctx, sb, r)
+	if err != nil || r.Status == TxDropped {
+		r.Status = TxPending
+		return r, fmt.Errorf("Couldn't push transaction %s. Error: %w", r.TxId, pushErr)
+	}
+	if settled {
+		return r, nil
+	}
+	return t.wait(ctx, sb, r)
+}
+
+// Wait waits for the spend bundle sb, already pushed, to settle, and returns the result. Failed polls are logged and retried. If ctx is done first, the result so far is returned with ctx's error.
+func (t *TxTracker) Wait(ctx context.Context, sb *SpendBundle) (*TxResult, error) {
+	r, err := t.newResult(sb)
+	if err != nil {
+		return nil, err
+	}
+	return t.wait(ctx, sb, r)
+}
+
+func (t *TxTracker) newResult(sb *SpendBundle) (*TxResult, error) {
+	id, err := sb.Name()
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't compute spend bundle name. Error: %w", err)
+	}
+	rs, err := sb.RemovalIds()
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't compute spend bundle removals. Error: %w", err)
+	}
+	return &TxResult{TxId: id, Removals: rs}, nil
+}
+
+func (t *TxTracker) wait(ctx context.Context, sb *SpendBundle, r *TxResult) (*TxResult, error) {
+	var timeout <-chan time.Time
+	if t.Timeout > 0 {
+		tm := time.NewTimer(t.Timeout)
+		defer tm.Stop()
+		timeout = tm.C
+	}
+	d := t.PollInterval
+	if d <= 0 {
+		d = DefaultPollInterval
+	}
+	tk := time.NewTicker(d)
+	defer tk.Stop()
+	for {
+		settled, err := t.poll(ctx, sb, r)
+		if settled {
+			return r, nil
+		}
+		if err != nil && ctx.Err() == nil {
+			logger(t.Logger).LogAttrs(ctx, slog.LevelWarn, "transaction poll failed", slog.String("tx_id", r.TxId), slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return r, ctx.Err()
+		case <-timeout:
+			r.Status = TxTimedOut
+			return r, nil
+		case <-tk.C:
+		}
+	}
+}
+
+// poll checks the mempool and the coins of the transaction once, updating r, and reports whether it has settled.
+func (t *TxTracker) poll(ctx context.Context, sb *SpendBundle, r *TxResult) (bool, error) {
+	// The mempool is asked first; a block taking the transaction out of it has then already spent its coins.
+	mi, err := (&MempoolItemByTxIdRequest{TxId: r.TxId, IncludePending: true}).SendContext(ctx, t.Caller)
+	inMempool := err == nil && mi.MempoolItem != nil
+	if err != nil {
+		if _, ok := AsAPIError(err); !ok {
+			return false, fmt.Errorf("Couldn't get mempool item %s. Error: %w", r.TxId, err)
+		}
+		// The node fails the call for transactions it doesn't hold.
+	}
+	if inMempool && r.Additions == nil {
+		r.Additions = make([]string, 0, len(mi.MempoolItem.Additions))
+		for _, c := range mi.MempoolItem.Additions {
+			if id, err := c.ID(); err == nil {
+				r.Additions = append(r.Additions, id)
+			}
+		}
+	}
+	cr, err := (&CoinRecordsByNameRequest{Names: r.Removals, IncludeSpent: true}).SendContext(ctx, t.Caller)
+	if err != nil {
+		return false, fmt.Errorf("Couldn't get coin records of transaction %s. Error: %w", r.TxId, err)
+	}
+	spent := make(map[string]*CoinRecord)
+	for _, rec := range cr.CoinRecords {
+		if rec.Coin == nil || !rec.Spent {
+			continue
+		}
+		if id, err := rec.Coin.ID(); err == nil {
+			spent[id] = rec
+		}
+	}
+	solutions := make(map[string]string, len(sb.CoinSolutions))
+	for i, cs := range sb.CoinSolutions {
+		solutions[r.Removals[i]] = cs.Solution
+	}
+	r.DoubleSpent, r.Height = nil, 0
+	ours := 0
+	for _, id := range r.Removals {
+		rec, ok := spent[id]
+		if !ok {
+			continue
+		}
+		ps, err := (&PuzzleAndSolutionRequest{CoinId: id, Height: rec.SpentBlockIndex}).SendContext(ctx, t.Caller)
+		if err != nil {
+			return false, fmt.Errorf("Couldn't get solution of coin %s. Error: %w", id, err)
+		}
+		if ps.CoinSolution == nil || !strings.EqualFold(strings.TrimPrefix(ps.CoinSolution.Solution, "0x"), strings.TrimPrefix(solutions[id], "0x")) {
+			r.DoubleSpent = append(r.DoubleSpent, id)
+			continue
+		}
+		ours++
+		r.Height = rec.SpentBlockIndex
+	}
+	switch {
+	case len(r.DoubleSpent) > 0:
+		r.Status = TxDoubleSpent
+		return true, nil
+	case ours == 0 && !inMempool:
+		r.Status = TxDropped
+		return true, nil
+	case ours < len(r.Removals):
+		// In the mempool, or some coins created by the bundle itself not yet seen spent.
+		r.Status = TxPending
+		return false, nil
+	}
+	bs, err := new(BlockchainStateRequest).SendContext(ctx, t.Caller)
+	if err != nil {
+		return false, fmt.Errorf("Couldn't get blockchain state. Error: %w", err)
+	}
+	if bs.BlockchainState == nil || bs.BlockchainState.Peak == nil || bs.BlockchainState.Peak.Height < r.Height {
+		return false, nil
+	}
+	r.Confirmations = bs.BlockchainState.Peak.Height - r.Height + 1
+	if r.Confirmations >= max(t.Confirmations, 1) {
+		r.Status = TxConfirmed
+		return true, nil
+	}
+	r.Status = TxPending
+	return false, nil
+}
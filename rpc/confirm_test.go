@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// txNode is a full node holding one transaction, in its mempool or spent in a block.
+type txNode struct {
+	mu        sync.Mutex
+	pushErr   string // If set, push_tx fails with this error.
+	mempool   bool
+	spent     uint   // Height at which the coin was spent, if not zero.
+	solution  string // Solution by which the coin was spent.
+	peak      uint
+	additions []*Coin
+}
+
+func (n *txNode) CallContext(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var r any
+	switch p {
+	case FullNodePushTx:
+		if n.pushErr != "" {
+			return json.Marshal(map[string]any{"success": false, "error": n.pushErr})
+		}
+		n.mempool = true
+		r = map[string]any{"status": "SUCCESS"}
+	case FullNodeGetMempoolItemByTxId:
+		if !n.mempool {
+			return []byte(`{"success": false, "error": "not in the mempool"}`), nil
+		}
+		r = map[string]any{"mempool_item": &MempoolItem{Additions: n.additions}}
+	case FullNodeCoinRecordByNames:
+		rec := &CoinRecord{Coin: testSpendBundle().CoinSolutions[0].Coin, ConfirmedBlockIndex: 1}
+		if n.spent > 0 {
+			rec.Spent, rec.SpentBlockIndex = true, n.spent
+		}
+		r = map[string]any{"coin_records": []*CoinRecord{rec}}
+	case FullNodeGetPuzzleAndSolution:
+		r = map[string]any{"coin_solution": &Solution{Solution: n.solution}}
+	case FullNodeGetBlockchainState:
+		r = map[string]any{"blockchain_state": map[string]any{"peak": map[string]any{"height": n.peak}}}
+	default:
+		return nil, fmt.Errorf("unexpected procedure %s", p)
+	}
+	b, _ := json.Marshal(r)
+	var m map[string]any
+	json.Unmarshal(b, &m)
+	m["success"] = true
+	return json.Marshal(m)
+}
+
+func testSpendBundle() *SpendBundle {
+	return &SpendBundle{
+		AggregatedSignature: "0xc0" + strings.Repeat("00", 95),
+		CoinSolutions: []*Solution{{
+			Coin:         &Coin{ParentCoinInfo: "0x" + strings.Repeat("11", 32), PuzzleHash: "0x" + strings.Repeat("22", 32), Amount: 1000},
+			PuzzleReveal: "0xff01ff8080",
+			Solution:     "0xff8080",
+		}},
+	}
+}
+
+func TestSpendBundleName(t *testing.T) {
+	sb := testSpendBundle()
+	want := "00000001" + strings.Repeat("11", 32) + strings.Repeat("22", 32) + "00000000000003e8" + "ff01ff8080" + "ff8080" + "c0" + strings.Repeat("00", 95)
+	b, err := sb.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(b) != want {
+		t.Errorf("Got serialization %x, want %s", b, want)
+	}
+	w, _ := hex.DecodeString(want)
+	h := sha256.Sum256(w)
+	if n, err := sb.Name(); err != nil || n != "0x"+hex.EncodeToString(h[:]) {
+		t.Errorf("Got name %s, %v", n, err)
+	}
+	sb.AggregatedSignature = "0xc0"
+	if _, err := sb.Name(); err == nil {
+		t.Error("Expected an error for a short signature")
+	}
+}
+
+func TestTxTrackerPoll(t *testing.T) {
+	sb := testSpendBundle()
+	n := &txNode{mempool: true, peak: 9, additions: []*Coin{{ParentCoinInfo: "0x" + strings.Repeat("33", 32), PuzzleHash: "0x" + strings.Repeat("44", 32), Amount: 1}}}
+	tr := &TxTracker{Caller: n, Confirmations: 2}
+	r, err := tr.newResult(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settled, err := tr.poll(context.Background(), sb, r); settled || err != nil || r.Status != TxPending || len(r.Additions) != 1 {
+		t.Fatalf("Expected pending with one addition, got %+v, %v", r, err)
+	}
+	n.mempool, n.spent, n.solution, n.peak = false, 10, sb.CoinSolutions[0].Solution, 10
+	if settled, err := tr.poll(context.Background(), sb, r); settled || err != nil || r.Height != 10 || r.Confirmations != 1 {
+		t.Fatalf("Expected one confirmation at 10, got %+v, %v", r, err)
+	}
+	n.peak = 11
+	if settled, err := tr.poll(context.Background(), sb, r); !settled || err != nil || r.Status != TxConfirmed || r.Confirmations != 2 {
+		t.Fatalf("Expected confirmed, got %+v, %v", r, err)
+	}
+}
+
+func TestTxTrackerTrack(t *testing.T) {
+	ctx := context.Background()
+	// The coin was already spent by another solution, so the push fails.
+	n := &txNode{pushErr: "DOUBLE_SPEND", spent: 5, solution: "0x80", peak: 5}
+	tr := &TxTracker{Caller: n, PollInterval: time.Millisecond}
+	if r, err := tr.Track(ctx, testSpendBundle()); err != nil || r.Status != TxDoubleSpent || len(r.DoubleSpent) != 1 {
+		t.Errorf("Expected double spent, got %+v, %v", r, err)
+	}
+	// Nothing came of a failed push.
+	n = &txNode{pushErr: "INVALID_FEE_TOO_CLOSE_TO_ZERO"}
+	tr.Caller = n
+	if _, err := tr.Track(ctx, testSpendBundle()); err == nil || !strings.Contains(err.Error(), "INVALID_FEE") {
+		t.Errorf("Expected the push error, got %v", err)
+	}
+	// Pushed, then evicted.
+	n = &txNode{}
+	tr.Caller = n
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		n.mu.Lock()
+		n.mempool = false
+		n.mu.Unlock()
+	}()
+	if r, err := tr.Track(ctx, testSpendBundle()); err != nil || r.Status != TxDropped || r.PushStatus != "SUCCESS" {
+		t.Errorf("Expected dropped, got %+v, %v", r, err)
+	}
+	// Stuck in the mempool.
+	n = &txNode{mempool: true}
+	tr = &TxTracker{Caller: n, PollInterval: time.Millisecond, Timeout: 10 * time.Millisecond}
+	if r, err := tr.Wait(ctx, testSpendBundle()); err != nil || r.Status != TxTimedOut {
+		t.Errorf("Expected timed out, got %+v, %v", r, err)
+	}
+}
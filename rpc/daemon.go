@@ -1,12 +1,6 @@
 package rpc
 
 var (
-	Daemon *Endpoint = &Endpoint{Name: "daemon", Host: defaultHost, Port: 55400}
+	// Daemon is a convenience daemon endpoint, configured and initialized on first use. See Endpoint.
+	Daemon *Endpoint = &Endpoint{Name: ServiceDaemon}
 )
-
-func init() {
-	err := Daemon.Init()
-	if err != nil {
-		logErr.Panicln(err)
-	}
-}
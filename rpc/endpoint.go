@@ -2,16 +2,47 @@ package rpc
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 )
 
+// DefaultTimeout limits calls made without a Context deadline, via an Endpoint with no CallTimeout set.
+var DefaultTimeout = 60 * time.Second
+
+// ChiaServerName is the name Chia issues its private service certificates for, and is sent as the TLS server name.
+const ChiaServerName = "chia.net"
+
+// VerifyChiaConnection returns a function for use as tls.Config.VerifyConnection, which verifies the peer's certificate chain against the roots pool. Host names are not verified, since Chia certificates don't carry them.
+func VerifyChiaConnection(roots *x509.CertPool) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("Chia service presented no certificate.")
+		}
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: x509.NewCertPool(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}
+		for _, c := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(c)
+		}
+		if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+			return fmt.Errorf("Couldn't verify Chia service certificate against the private CA. Error: %w", err)
+		}
+		return nil
+	}
+}
+
 // NewEndpoint returns and initializes a new *Endpoint. It returns an error on initialization faliure.
 func NewEndpoint(n string, h string, p uint) (*Endpoint, error) {
 	e := &Endpoint{
@@ -24,59 +55,157 @@ func NewEndpoint(n string, h string, p uint) (*Endpoint, error) {
 }
 
 // An Endpoint represents a Chia RPC endpoint. It implements Caller.
+// An Endpoint is initialized lazily on its first call, if Init has not been called already. Any of Host, Port and the certificate paths left unset are then taken from the Chia config of its Network's root directory, or failing that, from the defaults of a stock Chia install of that Network.
 type Endpoint struct {
-	Name string
-	Host string
-	Port uint
+	Name        string
+	Host        string
+	Port        uint
+	CACertPath  string          // Path to the private CA certificate.
+	CertPath    string          // Path to the private service certificate.
+	KeyPath     string          // Path to the private service key.
+	CallTimeout time.Duration   // Limit on each attempt of a call made without a Context deadline. If zero, DefaultTimeout is used; if negative, there is no limit.
+	Retry       *RetryPolicy    // Policy for retrying failed calls. If nil, calls are not retried.
+	Breaker     *CircuitBreaker // Circuit breaker shared by all calls. If nil, there is none.
+	Metrics     *Metrics        // Metrics recording each call. If nil, none are recorded.
+	Network     *Network        // Network of the service. If set, requests containing addresses of other networks are refused, and defaults are taken from it; otherwise they are those of Mainnet.
+	Logger      *slog.Logger    // Logger for calls. If nil, the package's default is used; see SetLogger.
+	Insecure    bool            // If true, the service certificate is not verified at all. Only for explicit use, e.g. against a throwaway node.
 	*http.Transport
 	*http.Client
+	mu sync.Mutex
 }
 
-// Initializes the Endpoint's HTTP Transport and Client properties.
+// Init initializes the Endpoint's HTTP Transport and Client properties.
 func (e *Endpoint) Init() error {
-	// Compile SSL file paths from default/custom data.
-	rp := filepath.Join(DefaultPath, "mainnet/config/ssl/ca", "private_ca.crt")
-	cp := filepath.Join(DefaultPath, "mainnet/config/ssl", e.Name, "private_"+e.Name+".crt")
-	kp := filepath.Join(DefaultPath, "mainnet/config/ssl", e.Name, "private_"+e.Name+".key")
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.init()
+}
 
-	// Load Certs.
-	c, err := tls.LoadX509KeyPair(cp, kp)
-	if err != nil {
+// ensureInit initializes the Endpoint, unless it has been already.
+func (e *Endpoint) ensureInit() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.Client != nil {
+		return nil
+	}
+	return e.init()
+}
+
+// init does the work of Init. The caller must hold e.mu.
+func (e *Endpoint) init() error {
+	if err := e.fill(); err != nil {
 		return err
 	}
 
-	// Get Chia CA.
-	ownCa, err := ioutil.ReadFile(rp)
+	// Load Certs.
+	c, err := tls.LoadX509KeyPair(e.CertPath, e.KeyPath)
 	if err != nil {
-		logErr.Println(err)
+		return err
 	}
 
-	// Make pool from Chia CA.
-	caRoots := x509.NewCertPool()
-	caRoots.AppendCertsFromPEM(ownCa)
-
 	// Setup Transport with TLS.
-	e.Transport = &http.Transport{
-		TLSClientConfig: &tls.Config{
-			Certificates: []tls.Certificate{c},
-			// @TODO: Get custom (chia) Cert pool working properly so we don't need this.
-			InsecureSkipVerify: true,
-			RootCAs:            caRoots,
-		},
+	tc := &tls.Config{
+		Certificates: []tls.Certificate{c},
+		ServerName:   ChiaServerName,
+		// Chia service certificates aren't issued for the hosts they run on, so Go's default verification, which includes the host name, is replaced by VerifyConnection.
+		InsecureSkipVerify: true,
 	}
+	if !e.Insecure {
+		// Get Chia CA.
+		ownCa, err := os.ReadFile(e.CACertPath)
+		if err != nil {
+			return fmt.Errorf("Couldn't read Chia private CA certificate. Error: %w", err)
+		}
+		// Make pool from Chia CA.
+		caRoots := x509.NewCertPool()
+		if !caRoots.AppendCertsFromPEM(ownCa) {
+			return fmt.Errorf("No certificates found in Chia private CA certificate file %s.", e.CACertPath)
+		}
+		tc.RootCAs = caRoots
+		tc.VerifyConnection = VerifyChiaConnection(caRoots)
+	}
+	e.Transport = &http.Transport{TLSClientConfig: tc}
 	// Setup Client.
 	e.Client = &http.Client{Transport: e.Transport}
 	return nil
 }
 
+// fill sets any unset Host, Port, certificate paths and Network, from the Chia config if it can be loaded, otherwise from defaults.
+func (e *Endpoint) fill() error {
+	if e.Host != "" && e.Port != 0 && e.CACertPath != "" && e.CertPath != "" && e.KeyPath != "" {
+		return nil
+	}
+	n := e.Network
+	if n == nil {
+		n = Mainnet
+	}
+	d := &Endpoint{Name: e.Name, Host: defaultHost, Port: n.Port(e.Name)}
+	r, err := n.Root()
+	if err != nil {
+		return err
+	}
+	if c, err := LoadConfig(r); err == nil {
+		if ce, err := c.endpoint(e.Name); err == nil {
+			d = ce
+		}
+		if e.Network == nil {
+			e.Network = c.Network()
+		}
+	}
+	if d.CACertPath == "" || d.CertPath == "" || d.KeyPath == "" {
+		dir, err := n.certDir()
+		if err != nil {
+			return err
+		}
+		if d.CACertPath == "" {
+			d.CACertPath = filepath.Join(dir, "ca", "private_ca.crt")
+		}
+		if d.CertPath == "" {
+			d.CertPath = filepath.Join(dir, e.Name, "private_"+e.Name+".crt")
+		}
+		if d.KeyPath == "" {
+			d.KeyPath = filepath.Join(dir, e.Name, "private_"+e.Name+".key")
+		}
+	}
+	if e.Host == "" {
+		e.Host = d.Host
+	}
+	if e.Port == 0 {
+		e.Port = d.Port
+	}
+	if e.Port == 0 {
+		return fmt.Errorf("No port set for endpoint %q.", e.Name)
+	}
+	if e.CACertPath == "" {
+		e.CACertPath = d.CACertPath
+	}
+	if e.CertPath == "" {
+		e.CertPath = d.CertPath
+	}
+	if e.KeyPath == "" {
+		e.KeyPath = d.KeyPath
+	}
+	return nil
+}
+
 // Post wraps the embedded *http.Client method. Used by Endpoint.Call() to make a POST request to a Chia RPC endpoint.
 func (e *Endpoint) Post(p Procedure, b io.Reader) (*http.Response, error) {
+	return e.PostContext(context.Background(), p, b)
+}
+
+// PostContext is like Post, but with a Context. Used by Endpoint.CallContext().
+func (e *Endpoint) PostContext(ctx context.Context, p Procedure, b io.Reader) (*http.Response, error) {
+	if err := e.ensureInit(); err != nil {
+		return nil, err
+	}
 	uri := strings.Join([]string{e.String(), string(p)}, "/")
-	r, err := e.Client.Post(uri, "application/json", b)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, b)
 	if err != nil {
-		logErr.Println(err)
+		return nil, err
 	}
-	return r, err
+	req.Header.Set("Content-Type", "application/json")
+	return e.Client.Do(req)
 }
 
 // Returns the Endpoint URI. Implements the fmt.Stringer interface.
@@ -87,21 +216,99 @@ func (e *Endpoint) String() string {
 // Call makes a call to the Chia RPC endpoint and returns the response as a byte slice.
 // Takes a Procedure, p, and a payload as a JSON byte slice, j.
 func (e *Endpoint) Call(p Procedure, j []byte) ([]byte, error) {
+	return e.CallContext(context.Background(), p, j)
+}
+
+// CallContext is like Call, but with a Context. If the response has a non-2xx HTTP status, or "success": false, the body is returned with an *APIError. Implements Caller.
+// If the Endpoint has a Network, requests containing addresses of other networks fail with ErrWrongNetwork, without being sent. Failed calls are retried according to the Endpoint's Retry policy, if set, and fail fast with ErrCircuitOpen while its Breaker, if set, is open. Each call, including its retries, is recorded by the Endpoint's Metrics, if set. Unless ctx already has a deadline, each attempt is limited to the Endpoint's CallTimeout, or DefaultTimeout if that is zero.
+func (e *Endpoint) CallContext(ctx context.Context, p Procedure, j []byte) (b []byte, err error) {
+	// Initialization failures won't go away by retrying.
+	if err := e.ensureInit(); err != nil {
+		return nil, err
+	}
+	if e.Network != nil {
+		if err := e.Network.CheckRequest(j); err != nil {
+			return nil, err
+		}
+	}
+	if e.Metrics != nil {
+		end := e.Metrics.Begin(e.String(), p)
+		defer func() { end(err) }()
+	}
+	call := func() error {
+		var err error
+		b, err = e.call(ctx, p, j)
+		return err
+	}
+	if e.Retry == nil {
+		err = call()
+		return b, err
+	}
+	err = e.Retry.Do(ctx, p, call)
+	return b, err
+}
+
+// call makes a single attempt of CallContext.
+func (e *Endpoint) call(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+	if e.Breaker != nil {
+		if err := e.Breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t := e.CallTimeout
+		if t == 0 {
+			t = DefaultTimeout
+		}
+		if t > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, t)
+			defer cancel()
+		}
+	}
+	start := time.Now()
+	b, s, err := e.post(ctx, p, j)
+	if e.Breaker != nil {
+		e.Breaker.Record(err)
+	}
+	e.log(ctx, p, time.Since(start), s, j, b, err)
+	return b, err
+}
+
+// post makes the POST request of a call, and reads the response. It returns the body, and the HTTP status code, if there is one.
+func (e *Endpoint) post(ctx context.Context, p Procedure, j []byte) ([]byte, int, error) {
 	// Make POST request
 	buf := bytes.NewReader(j)
-	r, err := e.Post(p, buf)
+	r, err := e.PostContext(ctx, p, buf)
 	if err != nil {
-		err = fmt.Errorf("Error with POST request to %s : %v", e, err)
-		logErr.Println(err)
-		return nil, err
+		err = fmt.Errorf("Error with POST request to %s : %w", e, err)
+		return nil, 0, err
 	}
 	defer r.Body.Close()
 	// Read response
 	b, err := io.ReadAll(r.Body)
-	// Return if error or status code shows an unsuccessful request.
-	if err != nil || r.StatusCode > 299 {
-		return b, err
+	if err != nil {
+		return b, r.StatusCode, err
 	}
+	// Return an *APIError if the status code or body shows an unsuccessful request.
+	return b, r.StatusCode, CheckResponse(p, e.String(), r.StatusCode, b)
+}
 
-	return b, nil
+// log logs an attempt of a call to the Endpoint's Logger. Failures are logged at warning level, and successes at debug level, along with the redacted request and response bodies.
+func (e *Endpoint) log(ctx context.Context, p Procedure, d time.Duration, s int, req, resp []byte, err error) {
+	l := logger(e.Logger)
+	attrs := []slog.Attr{
+		slog.String("procedure", string(p)),
+		slog.String("endpoint", e.String()),
+		slog.Duration("latency", d),
+		slog.Int("status", s),
+	}
+	if err != nil {
+		l.LogAttrs(ctx, slog.LevelWarn, "rpc call failed", append(attrs, slog.Any("error", err))...)
+		return
+	}
+	if !l.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	l.LogAttrs(ctx, slog.LevelDebug, "rpc call", append(attrs, slog.String("request", string(Redact(req))), slog.String("response", string(Redact(resp))))...)
 }
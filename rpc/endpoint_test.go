@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testCA is a throwaway private CA, issuing certificates the way Chia does.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	path string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Chia CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := &testCA{cert: cert, key: key, path: filepath.Join(t.TempDir(), "private_ca.crt")}
+	writePEM(t, ca.path, "CERTIFICATE", der)
+	return ca
+}
+
+// issue returns a certificate for chia.net, signed by the CA, and writes it and its key to dir.
+func (ca *testCA) issue(t *testing.T, dir string) (tls.Certificate, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "Chia"},
+		DNSNames:     []string{ChiaServerName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kder, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp, kp := filepath.Join(dir, "private.crt"), filepath.Join(dir, "private.key")
+	writePEM(t, cp, "CERTIFICATE", der)
+	writePEM(t, kp, "EC PRIVATE KEY", kder)
+	c, err := tls.LoadX509KeyPair(cp, kp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, cp, kp
+}
+
+func writePEM(t *testing.T, p, typ string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(p, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestServer starts a TLS server presenting a certificate issued by ca, and returns an uninitialized *Endpoint for it, with a client certificate from the same CA.
+// If h is nil, the server responds to everything with a successful sync status.
+func newTestServer(t *testing.T, ca *testCA, h http.HandlerFunc) *Endpoint {
+	t.Helper()
+	sc, _, _ := ca.issue(t, t.TempDir())
+	_, cp, kp := ca.issue(t, t.TempDir())
+	if h == nil {
+		h = func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"success": true, "synced": true}`))
+		}
+	}
+	s := httptest.NewUnstartedServer(h)
+	s.TLS = &tls.Config{Certificates: []tls.Certificate{sc}}
+	s.StartTLS()
+	t.Cleanup(s.Close)
+	host, p, err := net.SplitHostPort(s.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Endpoint{Name: ServiceWallet, Host: host, Port: uint(port), CACertPath: ca.path, CertPath: cp, KeyPath: kp}
+}
+
+func TestEndpointVerifiesPrivateCA(t *testing.T) {
+	ca := newTestCA(t)
+	e := newTestServer(t, ca, nil)
+	if _, err := e.Call(WalletSyncStatus, []byte(`{}`)); err != nil {
+		t.Errorf("Call with the service's own CA failed: %s", err)
+	}
+}
+
+func TestEndpointRejectsForeignCA(t *testing.T) {
+	e := newTestServer(t, newTestCA(t), nil)
+	e.CACertPath = newTestCA(t).path
+	if _, err := e.Call(WalletSyncStatus, []byte(`{}`)); err == nil {
+		t.Error("Call succeeded against a service certificate from a foreign CA")
+	}
+
+	// Explicitly insecure endpoints skip verification.
+	e = newTestServer(t, newTestCA(t), nil)
+	e.CACertPath = newTestCA(t).path
+	e.Insecure = true
+	if _, err := e.Call(WalletSyncStatus, []byte(`{}`)); err != nil {
+		t.Errorf("Insecure call failed: %s", err)
+	}
+}
+
+func TestEndpointMissingCA(t *testing.T) {
+	e := newTestServer(t, newTestCA(t), nil)
+	e.CACertPath = filepath.Join(t.TempDir(), "missing.crt")
+	if err := e.Init(); err == nil {
+		t.Error("Init succeeded without a CA certificate")
+	}
+}
+
+func TestEndpointContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	e := newTestServer(t, newTestCA(t), func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := e.CallContext(ctx, WalletSyncStatus, []byte(`{}`)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context deadline to be exceeded, got: %v", err)
+	}
+
+	// Without a deadline on the context, the endpoint's own timeout applies.
+	e.CallTimeout = 50 * time.Millisecond
+	if _, err := (&SyncStatusRequest{}).Send(e); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the endpoint timeout to be exceeded, got: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Errors for common conditions reported by Chia. An *APIError matches these with errors.Is, based on Chia's error message.
+var (
+	ErrWalletNotSynced   = errors.New("wallet not synced")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrUnknownWallet     = errors.New("unknown wallet id")
+)
+
+// apiErrorPatterns are the lower case fragments of Chia error messages which indicate each of the common conditions. A message matches a pattern if it contains all of its fragments.
+var apiErrorPatterns = map[error][][]string{
+	ErrWalletNotSynced:   {{"needs to be fully synced"}, {"not synced"}, {"wallet is syncing"}},
+	ErrInsufficientFunds: {{"insufficient funds"}, {"can't send more than"}, {"can't spend more than"}, {"greater than spendable balance"}, {"not enough coins"}},
+	ErrUnknownWallet:     {{"unknown wallet"}, {"wallet", "does not exist"}, {"wallet", "not found"}},
+}
+
+// An APIError is returned by requests which reach a Chia service, but fail; either with a non-2xx HTTP status, or with "success": false.
+type APIError struct {
+	Procedure  Procedure
+	Endpoint   string         // The Endpoint URI, if known.
+	StatusCode int            // The HTTP status code, if known.
+	Message    string         // Chia's "error" string.
+	Structured map[string]any // Chia's "structured_error" payload, if any.
+}
+
+// Error implements the built-in error interface.
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s failed", e.Procedure)
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " at %s", e.Endpoint)
+	}
+	if e.StatusCode != 0 && e.StatusCode != http.StatusOK {
+		fmt.Fprintf(&b, " with HTTP status %d", e.StatusCode)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+// Is reports whether the error matches target, one of the common condition errors, such as ErrWalletNotSynced.
+func (e *APIError) Is(target error) bool {
+	ps, ok := apiErrorPatterns[target]
+	if !ok {
+		return false
+	}
+	m := strings.ToLower(e.Message)
+	for _, p := range ps {
+		match := true
+		for _, f := range p {
+			if !strings.Contains(m, f) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// AsAPIError returns the *APIError in err's chain, if there is one.
+func AsAPIError(err error) (*APIError, bool) {
+	var e *APIError
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// CheckResponse returns an *APIError if the HTTP status s or body b of a call to procedure p show it failed, otherwise nil. Endpoint u and status s may be left empty if unknown. Callers other than Endpoint, such as fakes and replays, may use it to fail as an Endpoint would.
+func CheckResponse(p Procedure, u string, s int, b []byte) error {
+	r := new(struct {
+		Success    *bool          `json:"success"`
+		Error      string         `json:"error"`
+		Structured map[string]any `json:"structured_error"`
+	})
+	jerr := json.Unmarshal(b, r)
+	if s > 299 || s != 0 && s < 200 {
+		e := &APIError{Procedure: p, Endpoint: u, StatusCode: s, Message: r.Error, Structured: r.Structured}
+		if jerr != nil || e.Message == "" {
+			e.Message = strings.TrimSpace(string(b))
+		}
+		return e
+	}
+	if jerr == nil && r.Success != nil && !*r.Success {
+		return &APIError{Procedure: p, Endpoint: u, StatusCode: s, Message: r.Error, Structured: r.Structured}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		message string
+		target  error
+	}{
+		{"Wallet needs to be fully synced before sending transactions", ErrWalletNotSynced},
+		{"Can't send more than 100 mojos in a single transaction, got 200", ErrInsufficientFunds},
+		{"Transaction for 200 is greater than spendable balance of 100. There may be other transactions pending or our minimum coin amount is too high.", ErrInsufficientFunds},
+		{"Wallet id 9 does not exist", ErrUnknownWallet},
+	}
+	for _, tt := range tests {
+		err := error(&APIError{Procedure: WalletGetBalance, Message: tt.message})
+		if !errors.Is(err, tt.target) {
+			t.Errorf("%q does not match %v", tt.message, tt.target)
+		}
+		for _, other := range []error{ErrWalletNotSynced, ErrInsufficientFunds, ErrUnknownWallet} {
+			if other != tt.target && errors.Is(err, other) {
+				t.Errorf("%q also matches %v", tt.message, other)
+			}
+		}
+	}
+}
+
+func TestDoAPIError(t *testing.T) {
+	c := &cannedCaller{body: []byte(`{"success": false, "error": "Wallet needs to be fully synced.", "structured_error": {"code": "not_synced"}}`)}
+	r, err := (&SyncStatusRequest{}).SendContext(context.Background(), c)
+	if !errors.Is(err, ErrWalletNotSynced) {
+		t.Errorf("Expected ErrWalletNotSynced, got: %v", err)
+	}
+	e, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Expected an *APIError, got %T", err)
+	}
+	if e.Procedure != WalletSyncStatus || e.Structured["code"] != "not_synced" {
+		t.Errorf("Unexpected *APIError: %+v", e)
+	}
+	if r == nil || r.Error != "Wallet needs to be fully synced." {
+		t.Errorf("Expected the response along with the error, got: %+v", r)
+	}
+}
+
+func TestEndpointHTTPError(t *testing.T) {
+	e := newTestServer(t, newTestCA(t), func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+	})
+	_, err := (&SyncStatusRequest{}).Send(e)
+	ae, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Expected an *APIError, got: %v", err)
+	}
+	if ae.StatusCode != http.StatusServiceUnavailable || ae.Message != "service unavailable" || ae.Endpoint != e.String() {
+		t.Errorf("Unexpected *APIError: %+v", ae)
+	}
+}
@@ -1,12 +1,6 @@
 package rpc
 
 var (
-	Farmer *Endpoint = &Endpoint{Name: "farmer", Host: defaultHost, Port: 8559}
+	// Farmer is a convenience farmer endpoint, configured and initialized on first use. See Endpoint.
+	Farmer *Endpoint = &Endpoint{Name: ServiceFarmer}
 )
-
-func init() {
-	err := Farmer.Init()
-	if err != nil {
-		logErr.Panicln(err)
-	}
-}
@@ -0,0 +1,200 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// DefaultTxBlockInterval is the average time between transaction blocks, which are the only blocks to include transactions.
+var DefaultTxBlockInterval = 52 * time.Second
+
+// DefaultSpendCost is a rough allowance of cost per coin spend, for a FeeAdvisor advising on a spend bundle without the fee estimator, which would otherwise compute the cost. A standard spend costs somewhat less.
+var DefaultSpendCost uint = 10000000
+
+// FeeEstimateResponse represents the Chia RPC API's response to a FeeEstimateRequest. Estimates are total fees in mojos, one for each target time.
+type FeeEstimateResponse struct {
+	Estimates         []uint  `json:"estimates"`
+	TargetTimes       []uint  `json:"target_times"`
+	CurrentFeeRate    float64 `json:"current_fee_rate"`
+	MempoolSize       uint    `json:"mempool_size"`
+	MempoolFees       uint    `json:"mempool_fees"`
+	MempoolMaxSize    uint    `json:"mempool_max_size"`
+	NumSpends         uint    `json:"num_spends"`
+	FullNodeSynced    bool    `json:"full_node_synced"`
+	PeakHeight        uint    `json:"peak_height"`
+	LastPeakTimestamp uint    `json:"last_peak_timestamp"`
+	NodeTimeUtc       uint    `json:"node_time_utc"`
+	LastBlockCost     uint    `json:"last_block_cost"`
+	FeesLastBlock     uint    `json:"fees_last_block"`
+	FeeRateLastBlock  float64 `json:"fee_rate_last_block"`
+	LastTxBlockHeight uint    `json:"last_tx_block_height"`
+	Response
+}
+
+// FeeEstimateRequest is a type for making a request for fee estimates, for a spend bundle or a cost, to be included within each of the target times, in seconds.
+type FeeEstimateRequest struct {
+	SpendBundle *SpendBundle `json:"spend_bundle,omitempty"`
+	Cost        uint         `json:"cost,omitempty"`
+	SpendType   string       `json:"spend_type,omitempty"` // A kind of transaction, such as "send_xch_transaction", for which the node supplies the cost.
+	SpendCount  uint         `json:"spend_count,omitempty"`
+	TargetTimes []uint       `json:"target_times"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (f *FeeEstimateRequest) Procedure() Procedure {
+	return FullNodeGetFeeEstimate
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (f *FeeEstimateRequest) Send(e *Endpoint) (*FeeEstimateResponse, error) {
+	return f.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (f *FeeEstimateRequest) SendContext(ctx context.Context, caller Caller) (*FeeEstimateResponse, error) {
+	return Do[*FeeEstimateRequest, FeeEstimateResponse](ctx, caller, f)
+}
+
+// String implements the fmt.Stringer interface.
+func (f *FeeEstimateRequest) String() string {
+	return requestString(f)
+}
+
+// FeeSource tells where a FeeAdvice came from.
+type FeeSource string
+
+const (
+	FeeFromEstimator FeeSource = "estimator" // The full node's fee estimator.
+	FeeFromMempool   FeeSource = "mempool"   // Statistics of the mempool, computed by the FeeAdvisor.
+)
+
+// FeeAdvice is a fee recommended by a FeeAdvisor.
+type FeeAdvice struct {
+	Fee     uint // Recommended fee, in mojos.
+	Cost    uint // Cost the fee was reckoned for. For a spend bundle advised by the estimator, zero, since the node doesn't report it.
+	Within  time.Duration
+	Source  FeeSource
+	FeeRate float64 // For FeeFromMempool, the fee rate, in mojos per cost, which the fee beats.
+}
+
+// A FeeAdvisor recommends fees for transactions, from a full node's fee estimator, or, when that is unavailable, such as on older nodes, from the fee rates of the mempool.
+type FeeAdvisor struct {
+	Caller          Caller        // The full node.
+	TxBlockInterval time.Duration // Average time between transaction blocks, for reckoning from the mempool. If zero, DefaultTxBlockInterval is used.
+	Logger          *slog.Logger  // Logger for estimator failures. If nil, the package's default is used; see SetLogger.
+}
+
+// NewFeeAdvisor returns a new *FeeAdvisor of the full node c.
+func NewFeeAdvisor(c Caller) *FeeAdvisor {
+	return &FeeAdvisor{Caller: c}
+}
+
+// Advise recommends a fee for the spend bundle sb to be included within the target time.
+func (a *FeeAdvisor) Advise(ctx context.Context, sb *SpendBundle, within time.Duration) (*FeeAdvice, error) {
+	if sb == nil {
+		return nil, fmt.Errorf("Couldn't advise a fee; no spend bundle given.")
+	}
+	return a.advise(ctx, &FeeEstimateRequest{SpendBundle: sb}, uint(len(sb.CoinSolutions))*DefaultSpendCost, within)
+}
+
+// AdviseCost recommends a fee for a transaction of the given cost to be included within the target time.
+func (a *FeeAdvisor) AdviseCost(ctx context.Context, cost uint, within time.Duration) (*FeeAdvice, error) {
+	return a.advise(ctx, &FeeEstimateRequest{Cost: cost}, cost, within)
+}
+
+func (a *FeeAdvisor) advise(ctx context.Context, req *FeeEstimateRequest, cost uint, within time.Duration) (*FeeAdvice, error) {
+	req.TargetTimes = []uint{uint(max(within, time.Second) / time.Second)}
+	r, err := req.SendContext(ctx, a.Caller)
+	if err == nil && len(r.Estimates) > 0 {
+		return &FeeAdvice{Fee: r.Estimates[0], Cost: req.Cost, Within: within, Source: FeeFromEstimator}, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("Fee estimator returned no estimates.")
+	}
+	if ctx.Err() != nil || !estimatorUnavailable(err) {
+		return nil, err
+	}
+	logger(a.Logger).LogAttrs(ctx, slog.LevelDebug, "fee estimator unavailable, using mempool", slog.Any("error", err))
+	return a.fromMempool(ctx, cost, within)
+}
+
+// estimatorUnavailable reports whether err, from a fee estimate, shows the estimator to be unavailable, rather than the request to be at fault, such as with an invalid spend bundle. The estimator is unavailable if it couldn't be reached, if the full node doesn't know the procedure, as with older nodes, or if it returned no estimates.
+func estimatorUnavailable(err error) bool {
+	e, ok := AsAPIError(err)
+	return !ok || e.StatusCode == http.StatusNotFound
+}
+
+// fromMempool recommends a fee for a transaction of the given cost, beating the fee rates of enough of the mempool that the transaction fits in the transaction blocks expected within the target time.
+func (a *FeeAdvisor) fromMempool(ctx context.Context, cost uint, within time.Duration) (*FeeAdvice, error) {
+	s, err := new(BlockchainStateRequest).SendContext(ctx, a.Caller)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get blockchain state. Error: %w", err)
+	}
+	if s.BlockchainState == nil {
+		return nil, fmt.Errorf("Couldn't get blockchain state; the full node returned none.")
+	}
+	m, err := new(AllMempoolItemsRequest).SendContext(ctx, a.Caller)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get mempool items. Error: %w", err)
+	}
+	d := a.TxBlockInterval
+	if d <= 0 {
+		d = DefaultTxBlockInterval
+	}
+	blocks := max(uint(within/d), 1)
+	bs := s.BlockchainState
+	// Room left for others, ahead of us, in the blocks within the target time.
+	var room uint
+	if capacity := blocks * bs.BlockMaxCost; capacity > cost {
+		room = capacity - cost
+	}
+	items := make([]*MempoolItem, 0, len(m.MempoolItems))
+	for _, it := range m.MempoolItems {
+		items = append(items, it)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].FeeRate() > items[j].FeeRate()
+	})
+	var rate float64
+	var used uint
+	for _, it := range items {
+		if used += it.Cost; used > room {
+			rate = it.FeeRate()
+			break
+		}
+	}
+	// A full mempool only admits transactions paying at least its minimum fee rate.
+	if mr := minFeeRate(bs.MempoolMinFees, cost); mr > rate {
+		rate = mr
+	}
+	adv := &FeeAdvice{Cost: cost, Within: within, Source: FeeFromMempool, FeeRate: rate}
+	if rate > 0 {
+		// One mojo more than matching the rate, to outbid it.
+		adv.Fee = uint(math.Ceil(rate*float64(cost))) + 1
+	}
+	return adv, nil
+}
+
+// minFeeRate returns the mempool's minimum fee rate for a transaction of the given cost, from the bucket the full node reports nearest that cost. Chia reports only "cost_5000000" at present, so that bucket is used for any cost. It returns zero if no buckets are reported.
+func minFeeRate(fees map[string]float64, cost uint) float64 {
+	var rate float64
+	var best uint
+	found := false
+	for k, r := range fees {
+		var c uint
+		if _, err := fmt.Sscanf(k, "cost_%d", &c); err != nil {
+			continue
+		}
+		d := max(c, cost) - min(c, cost)
+		// Of equally near buckets, the higher rate is used.
+		if !found || d < best || (d == best && r > rate) {
+			rate, best, found = r, d, true
+		}
+	}
+	return rate
+}
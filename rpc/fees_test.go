@@ -0,0 +1,61 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Jsewill/chia/rpc"
+	"github.com/Jsewill/chia/rpc/rpctest"
+)
+
+func TestFeeAdvisor(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceFullNode)
+	a := rpc.NewFeeAdvisor(s.Endpoint())
+	ctx := context.Background()
+	adv, err := a.AdviseCost(ctx, 10000000, 5*time.Minute)
+	if err != nil || adv.Source != rpc.FeeFromEstimator || adv.Fee != 250000 {
+		t.Fatalf("Unexpected advice from the estimator: %+v, %v", adv, err)
+	}
+	if c := s.CallsTo(rpc.FullNodeGetFeeEstimate); len(c) != 1 || string(c[0].Request) != `{"cost":10000000,"target_times":[300]}` {
+		t.Errorf("Unexpected calls: %v", c)
+	}
+	// Without the estimator, as on nodes which don't know the procedure, the mempool is consulted. One block holds two of the three items, so the fee must beat the third.
+	s.Remove(rpc.FullNodeGetFeeEstimate)
+	s.HandleJSON(rpc.FullNodeGetBlockchainState, `{"success": true, "blockchain_state": {"block_max_cost": 30000000, "mempool_min_fees": {"cost_5000000": 0}}}`)
+	s.HandleJSON(rpc.FullNodeGetAllMempoolItems, `{"success": true, "mempool_items": {
+		"0x01": {"fee": 50000000, "cost": 10000000},
+		"0x02": {"fee": 30000000, "cost": 10000000},
+		"0x03": {"fee": 10000000, "cost": 10000000}
+	}}`)
+	adv, err = a.AdviseCost(ctx, 10000000, time.Minute)
+	if err != nil || adv.Source != rpc.FeeFromMempool || adv.FeeRate != 1 || adv.Fee != 10000001 {
+		t.Fatalf("Unexpected advice from the mempool: %+v, %v", adv, err)
+	}
+	// Within two blocks, there is room for all.
+	adv, err = a.AdviseCost(ctx, 10000000, 2*time.Minute)
+	if err != nil || adv.Fee != 0 {
+		t.Errorf("Expected no fee, got %+v, %v", adv, err)
+	}
+	// The minimum fee rate of the bucket nearest the cost applies.
+	s.HandleJSON(rpc.FullNodeGetBlockchainState, `{"success": true, "blockchain_state": {"block_max_cost": 30000000, "mempool_min_fees": {"cost_5000000": 2, "cost_20000000": 3}}}`)
+	adv, err = a.AdviseCost(ctx, 10000000, 2*time.Minute)
+	if err != nil || adv.FeeRate != 2 || adv.Fee != 20000001 {
+		t.Errorf("Unexpected advice with minimum fees: %+v, %v", adv, err)
+	}
+	// A node without blockchain state, such as while syncing, is an error rather than a panic.
+	s.HandleJSON(rpc.FullNodeGetBlockchainState, `{"success": true}`)
+	if _, err := a.AdviseCost(ctx, 10000000, time.Minute); err == nil {
+		t.Error("Expected an error without blockchain state")
+	}
+	// An estimator which rejects the request is an error, rather than a reason to consult the mempool.
+	s.Fail(rpc.FullNodeGetFeeEstimate, "Invalid spend bundle")
+	if _, err := a.Advise(ctx, &rpc.SpendBundle{}, time.Minute); err == nil {
+		t.Error("Expected the estimator's error")
+	} else if ae, ok := rpc.AsAPIError(err); !ok || ae.Procedure != rpc.FullNodeGetFeeEstimate {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if _, err := a.Advise(ctx, nil, time.Minute); err == nil {
+		t.Error("Expected an error without a spend bundle")
+	}
+}
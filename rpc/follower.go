@@ -0,0 +1,296 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is the wait between polls of a Follower, CoinWatcher or TxTracker with no PollInterval set. Chia makes a block about every 18.75 seconds.
+var DefaultPollInterval = 5 * time.Second
+
+// DefaultReorgDepth is the number of recent blocks a Follower with no ReorgDepth set remembers, to detect reorgs.
+var DefaultReorgDepth uint = 256
+
+// ErrReorgTooDeep is returned by a Follower when the chain forks below the blocks it remembers.
+var ErrReorgTooDeep = errors.New("reorg deeper than tracked blocks")
+
+// BlockEventType is the type of a BlockEvent.
+type BlockEventType int
+
+const (
+	BlockAdded BlockEventType = iota // A block was added to the peak chain.
+	Rollback                         // Blocks were removed from the peak chain, by a reorg.
+)
+
+// String implements the fmt.Stringer interface.
+func (t BlockEventType) String() string {
+	switch t {
+	case BlockAdded:
+		return "block added"
+	case Rollback:
+		return "rollback"
+	}
+	return fmt.Sprintf("BlockEventType(%d)", int(t))
+}
+
+// A BlockEvent is emitted by a Follower, for each block added to the peak chain, in order of height, and for each rollback.
+type BlockEvent struct {
+	Type       BlockEventType
+	Height     uint          // Height of the added block. For a Rollback, the height of the highest block removed.
+	ForkHeight uint          // For a Rollback, the height of the highest block still on the chain. Blocks above it were removed, and should be undone.
+	Block      *BlockRecord  // The added block.
+	Additions  []*CoinRecord // Coins created by the added block. Only transaction blocks create and spend coins.
+	Removals   []*CoinRecord // Coins spent by the added block.
+	Peak       uint          // Height of the peak, when the event was emitted.
+}
+
+// A Follower follows the peak chain of a full node, by polling, and emits an event for each new block, with its additions and removals, and for each reorg, so that consumers can undo the state of removed blocks.
+// Blocks are emitted in order of height, and each block's parent is the previously emitted block, unless a Rollback came between them.
+type Follower struct {
+	Caller       Caller          // The full node.
+	StartHeight  uint            // Height of the first block to emit. If zero, following starts at the peak, when first polled. See also Resume.
+	PollInterval time.Duration   // Wait between polls. If zero, DefaultPollInterval is used.
+	ReorgDepth   uint            // Number of recent blocks remembered to detect reorgs. If zero, DefaultReorgDepth is used.
+	Logger       *slog.Logger    // Logger for failed polls. If nil, the package's default is used; see SetLogger.
+	polling      sync.Mutex      // Serializes polls.
+	mu           sync.Mutex      // Guards hashes, next and started. Never held while emitting.
+	hashes       map[uint]string // Header hashes of the remembered blocks, by height.
+	next         uint            // Height of the next block to emit.
+	started      bool
+}
+
+// NewFollower returns a new *Follower of the full node c, starting at the peak.
+func NewFollower(c Caller) *Follower {
+	return &Follower{Caller: c}
+}
+
+// Checkpoint returns the header hashes of the recent blocks the Follower remembers, by height, for saving before a restart, and passing to Resume.
+func (f *Follower) Checkpoint() map[uint]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := make(map[uint]string, len(f.hashes))
+	for h, hash := range f.hashes {
+		c[h] = hash
+	}
+	return c
+}
+
+// Resume sets the Follower to continue after the highest block of a Checkpoint. If blocks of the checkpoint have since been removed by a reorg, a Rollback is emitted first, so long as the fork is within the checkpoint. It should be called before Run, not while polling.
+func (f *Follower) Resume(checkpoint map[uint]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hashes = make(map[uint]string, len(checkpoint))
+	f.next = 0
+	for h, hash := range checkpoint {
+		f.hashes[h] = hash
+		f.next = max(f.next, h+1)
+	}
+	f.started = len(checkpoint) > 0
+}
+
+// Tip returns the height and header hash of the last block emitted, and whether there is one.
+func (f *Follower) Tip() (uint, string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.next == 0 {
+		return 0, "", false
+	}
+	h, ok := f.hashes[f.next-1]
+	return f.next - 1, h, ok
+}
+
+// Run polls the full node until ctx is done, calling emit with each event. Failed polls, such as while the node restarts, are logged and retried at the next interval. Run returns ctx's error, ErrReorgTooDeep, or the first error returned by emit.
+func (f *Follower) Run(ctx context.Context, emit func(BlockEvent) error) error {
+	d := f.PollInterval
+	if d <= 0 {
+		d = DefaultPollInterval
+	}
+	t := time.NewTicker(d)
+	defer t.Stop()
+	for {
+		if err := f.Poll(ctx, emit); err != nil {
+			var ee emitError
+			switch {
+			case errors.As(err, &ee):
+				return ee.err
+			case errors.Is(err, ErrReorgTooDeep), ctx.Err() != nil:
+				return err
+			}
+			logger(f.Logger).LogAttrs(ctx, slog.LevelWarn, "follower poll failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Events runs the Follower until ctx is done, and returns a channel of its events, and a channel which receives the error Run returns. Both are closed when Run returns.
+func (f *Follower) Events(ctx context.Context) (<-chan BlockEvent, <-chan error) {
+	events, errc := make(chan BlockEvent), make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(events)
+		errc <- f.Run(ctx, func(e BlockEvent) error {
+			select {
+			case events <- e:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return events, errc
+}
+
+// emitError wraps an error returned by an emit function, to tell it from a failed poll.
+type emitError struct {
+	err error
+}
+
+func (e emitError) Error() string {
+	return e.err.Error()
+}
+
+func (e emitError) Unwrap() error {
+	return e.err
+}
+
+// Poll polls the full node once, calling emit with any rollback, then with each block added since the last poll. The Follower's lock is not held while emitting, so emit may call Checkpoint or Tip.
+func (f *Follower) Poll(ctx context.Context, emit func(BlockEvent) error) error {
+	f.polling.Lock()
+	defer f.polling.Unlock()
+	s, err := new(BlockchainStateRequest).SendContext(ctx, f.Caller)
+	if err != nil {
+		return fmt.Errorf("Couldn't get blockchain state. Error: %w", err)
+	}
+	if s.BlockchainState == nil || s.BlockchainState.Peak == nil {
+		// No chain yet.
+		return nil
+	}
+	peak := s.BlockchainState.Peak.Height
+	f.mu.Lock()
+	if !f.started {
+		f.started = true
+		f.hashes = make(map[uint]string)
+		f.next = f.StartHeight
+		if f.next == 0 {
+			f.next = peak
+		}
+	}
+	f.mu.Unlock()
+	if err := f.checkReorg(ctx, peak, emit); err != nil {
+		return err
+	}
+	for {
+		f.mu.Lock()
+		h := f.next
+		prev, ok := f.hashes[h-1]
+		f.mu.Unlock()
+		if h > peak {
+			return nil
+		}
+		b, err := f.blockRecord(ctx, h)
+		if err != nil {
+			return err
+		}
+		if ok && h > 0 && b.PrevHash != prev {
+			// The chain changed under us; the next poll rolls back.
+			return nil
+		}
+		e := BlockEvent{Type: BlockAdded, Height: h, Block: b, Peak: peak}
+		if b.IsTransactionBlock() {
+			ar, err := (&AdditionsAndRemovalsRequest{HeaderHash: b.HeaderHash}).SendContext(ctx, f.Caller)
+			if err != nil {
+				return fmt.Errorf("Couldn't get additions and removals at height %d. Error: %w", h, err)
+			}
+			e.Additions, e.Removals = ar.Additions, ar.Removals
+		}
+		if err := emit(e); err != nil {
+			return emitError{err}
+		}
+		f.mu.Lock()
+		f.hashes[h] = b.HeaderHash
+		f.next = h + 1
+		f.prune()
+		f.mu.Unlock()
+	}
+}
+
+// blockRecord returns the block record at height h.
+func (f *Follower) blockRecord(ctx context.Context, h uint) (*BlockRecord, error) {
+	br, err := (&BlockRecordByHeightRequest{Height: h}).SendContext(ctx, f.Caller)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get block record at height %d. Error: %w", h, err)
+	}
+	if br.BlockRecord == nil {
+		return nil, fmt.Errorf("No block record at height %d.", h)
+	}
+	return br.BlockRecord, nil
+}
+
+// checkReorg emits a Rollback if the last emitted block is no longer on the peak chain. The caller must hold f.polling, and not f.mu.
+func (f *Follower) checkReorg(ctx context.Context, peak uint, emit func(BlockEvent) error) error {
+	f.mu.Lock()
+	next := f.next
+	f.mu.Unlock()
+	if next == 0 {
+		return nil
+	}
+	last := next - 1
+	h := min(last, peak)
+	for {
+		f.mu.Lock()
+		want, ok := f.hashes[h]
+		f.mu.Unlock()
+		if !ok {
+			if h < last {
+				return fmt.Errorf("Chain forked below height %d: %w", h+1, ErrReorgTooDeep)
+			}
+			// Nothing remembered to compare, such as after a rollback to before the first block.
+			return nil
+		}
+		b, err := f.blockRecord(ctx, h)
+		if err != nil {
+			return err
+		}
+		if b.HeaderHash == want {
+			break
+		}
+		if h == 0 {
+			return fmt.Errorf("Chain forked at genesis: %w", ErrReorgTooDeep)
+		}
+		h--
+	}
+	if h == last {
+		return nil
+	}
+	if err := emit(BlockEvent{Type: Rollback, Height: last, ForkHeight: h, Peak: peak}); err != nil {
+		return emitError{err}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := h + 1; i <= last; i++ {
+		delete(f.hashes, i)
+	}
+	f.next = h + 1
+	return nil
+}
+
+// prune forgets blocks too deep to be reorged. The caller must hold f.mu.
+func (f *Follower) prune() {
+	d := f.ReorgDepth
+	if d == 0 {
+		d = DefaultReorgDepth
+	}
+	for h := range f.hashes {
+		if h+d < f.next {
+			delete(f.hashes, h)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeChain is a full node with a chain of blocks which tests can reorg. Every block is a transaction block, whose only addition is a coin of its height in mojos, at fakePuzzleHash(height%2), hinted with fakeHint at even heights, and whose only removal is the coin of the block before.
+type fakeChain struct {
+	mu        sync.Mutex
+	hashes    []string // Header hashes, by height.
+	hintCalls int      // Number of calls for coin records by hints.
+}
+
+func newFakeChain(prefix string, n int) *fakeChain {
+	c := new(fakeChain)
+	c.extend(prefix, n)
+	return c
+}
+
+// extend adds n blocks to the chain, with header hashes made from prefix and height.
+func (c *fakeChain) extend(prefix string, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 0; i < n; i++ {
+		c.hashes = append(c.hashes, fmt.Sprintf("%s%d", prefix, len(c.hashes)))
+	}
+}
+
+// fork removes blocks above height h.
+func (c *fakeChain) fork(h int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hashes = c.hashes[:h+1]
+}
+
+func (c *fakeChain) record(h uint) *BlockRecord {
+	ts := uint(1700000000 + h)
+	b := &BlockRecord{HeaderHash: c.hashes[h], Height: h, Timestamp: &ts}
+	if h > 0 {
+		b.PrevHash = c.hashes[h-1]
+	}
+	return b
+}
+
+// fakePuzzleHash returns the puzzle hash of the coins of the fakeChain blocks of heights with parity i.
+func fakePuzzleHash(i uint) string {
+	return fmt.Sprintf("0x%064x", i+1)
+}
+
+const fakeHint = "0x00000000000000000000000000000000000000000000000000000000000000ff"
+
+// coin returns the coin record of the coin created at height h.
+func (c *fakeChain) coin(h uint) *CoinRecord {
+	return &CoinRecord{Coin: &Coin{ParentCoinInfo: fmt.Sprintf("0x%064x", 0), PuzzleHash: fakePuzzleHash(h % 2), Amount: h}, ConfirmedBlockIndex: h}
+}
+
+func (c *fakeChain) CallContext(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var req struct {
+		Height      uint     `json:"height"`
+		HeaderHash  string   `json:"header_hash"`
+		Hints       []string `json:"hints"`
+		StartHeight uint     `json:"start_height"`
+	}
+	json.Unmarshal(j, &req)
+	var r any
+	switch p {
+	case FullNodeGetBlockchainState:
+		r = &BlockchainStateResponse{BlockchainState: &BlockchainState{Peak: c.record(uint(len(c.hashes) - 1))}}
+	case FullNodeGetBlockRecordByHeight:
+		if req.Height >= uint(len(c.hashes)) {
+			return []byte(`{"success": false, "error": "height not found"}`), nil
+		}
+		r = &BlockRecordResponse{BlockRecord: c.record(req.Height)}
+	case FullNodeGetAdditionsAndRemovals:
+		for h, hh := range c.hashes {
+			if hh == req.HeaderHash {
+				r = &AdditionsAndRemovalsResponse{Additions: []*CoinRecord{c.coin(uint(h))}}
+				if h > 0 {
+					spent := c.coin(uint(h - 1))
+					spent.Spent, spent.SpentBlockIndex = true, uint(h)
+					r.(*AdditionsAndRemovalsResponse).Removals = []*CoinRecord{spent}
+				}
+			}
+		}
+	case FullNodeCoinRecordByHints:
+		cr := &CoinRecordsResponse{}
+		c.hintCalls++
+		if slices.Contains(req.Hints, fakeHint) && req.StartHeight%2 == 0 && req.StartHeight < uint(len(c.hashes)) {
+			cr.CoinRecords = []*CoinRecord{c.coin(req.StartHeight)}
+		}
+		r = cr
+	default:
+		return nil, fmt.Errorf("unexpected procedure %s", p)
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	// Responses marshaled from types embedding Response would otherwise be unsuccessful.
+	var m map[string]any
+	json.Unmarshal(b, &m)
+	m["success"] = true
+	return json.Marshal(m)
+}
+
+func collect(t *testing.T, f *Follower) []BlockEvent {
+	t.Helper()
+	var es []BlockEvent
+	if err := f.Poll(context.Background(), func(e BlockEvent) error {
+		es = append(es, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("Poll failed: %s", err)
+	}
+	return es
+}
+
+func TestFollowerReorg(t *testing.T) {
+	c := newFakeChain("a", 6)
+	f := &Follower{Caller: c, StartHeight: 1}
+	es := collect(t, f)
+	if len(es) != 5 || es[0].Height != 1 || es[4].Block.HeaderHash != "a5" || es[4].Additions[0].Coin.Amount != 5 {
+		t.Fatalf("Unexpected events %+v", es)
+	}
+	if es := collect(t, f); len(es) != 0 {
+		t.Errorf("Unexpected events without new blocks %+v", es)
+	}
+	// Replace blocks 4 and 5 with three others.
+	c.fork(3)
+	c.extend("b", 3)
+	es = collect(t, f)
+	if len(es) != 4 || es[0].Type != Rollback || es[0].ForkHeight != 3 || es[0].Height != 5 {
+		t.Fatalf("Expected a rollback to height 3, got %+v", es)
+	}
+	if es[1].Type != BlockAdded || es[1].Height != 4 || es[3].Block.HeaderHash != "b6" {
+		t.Errorf("Unexpected events after rollback %+v", es[1:])
+	}
+	if h, hash, ok := f.Tip(); !ok || h != 6 || hash != "b6" {
+		t.Errorf("Unexpected tip %d %s", h, hash)
+	}
+}
+
+func TestFollowerResume(t *testing.T) {
+	c := newFakeChain("a", 4)
+	c.fork(2)
+	c.extend("b", 3)
+	// Resuming after a block which was since reorged away rolls it back first.
+	f := NewFollower(c)
+	f.Resume(map[uint]string{1: "a1", 2: "a2", 3: "a3"})
+	if es := collect(t, f); len(es) != 4 || es[0].Type != Rollback || es[0].ForkHeight != 2 || es[3].Block.HeaderHash != "b5" {
+		t.Errorf("Unexpected events %+v", es)
+	}
+	if cp := f.Checkpoint(); cp[5] != "b5" || cp[3] != "b3" {
+		t.Errorf("Unexpected checkpoint %v", cp)
+	}
+	// The block below the resumed one isn't known, so the reorg can't be undone.
+	f = NewFollower(c)
+	f.Resume(map[uint]string{3: "zz"})
+	if err := f.Poll(context.Background(), func(BlockEvent) error { return nil }); err == nil {
+		t.Error("Expected ErrReorgTooDeep")
+	}
+}
+
+func TestFollowerEvents(t *testing.T) {
+	c := newFakeChain("a", 3)
+	f := &Follower{Caller: c, StartHeight: 1, PollInterval: time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errc := f.Events(ctx)
+	for want := uint(1); want <= 4; want++ {
+		e := <-events
+		if e.Height != want {
+			t.Fatalf("Got block %d, want %d", e.Height, want)
+		}
+		// Saving a checkpoint between events mustn't block the Follower.
+		if cp := f.Checkpoint(); want > 1 && cp[want-1] == "" {
+			t.Errorf("Checkpoint %v lacks block %d", cp, want-1)
+		}
+		if want == 2 {
+			c.extend("a", 2)
+		}
+	}
+	cancel()
+	for range events {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFollowerEmitCallsBack(t *testing.T) {
+	f := &Follower{Caller: newFakeChain("a", 4), StartHeight: 1}
+	err := f.Poll(context.Background(), func(e BlockEvent) error {
+		// The block is remembered once emitted successfully.
+		if h, _, ok := f.Tip(); e.Height > 1 && (!ok || h != e.Height-1) {
+			t.Errorf("Unexpected tip %d while emitting block %d", h, e.Height)
+		}
+		if _, ok := f.Checkpoint()[e.Height]; ok {
+			t.Errorf("Checkpoint has block %d before it was emitted", e.Height)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Poll failed: %s", err)
+	}
+}
+
+func TestFollowerNoBlockRecord(t *testing.T) {
+	c := CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+		if p == FullNodeGetBlockchainState {
+			return []byte(`{"success": true, "blockchain_state": {"peak": {"height": 3}}}`), nil
+		}
+		return []byte(`{"success": true, "block_record": null}`), nil
+	})
+	f := &Follower{Caller: c, StartHeight: 1}
+	if err := f.Poll(context.Background(), func(BlockEvent) error { return nil }); err == nil {
+		t.Error("Expected an error for a missing block record")
+	}
+	// Nor when checking for a reorg.
+	f.Resume(map[uint]string{2: "a2"})
+	if err := f.Poll(context.Background(), func(BlockEvent) error { return nil }); err == nil {
+		t.Error("Expected an error for a missing block record")
+	}
+}
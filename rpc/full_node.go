@@ -1,53 +1,53 @@
 package rpc
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 const (
-	FullNodeCoinRecordByName      Procedure = "get_coin_record_by_name"
-	FullNodeCoinRecordByNames     Procedure = "get_coin_record_by_names"
-	FullNodeCoinRecordByParentIds Procedure = "get_coin_record_by_parent_ids"
-	FullNodeCoinRecordByHints     Procedure = "get_coin_record_by_hints"
-	FullNodePushTx                Procedure = "push_tx"
+	FullNodeCoinRecordByName          Procedure = "get_coin_record_by_name"
+	FullNodeCoinRecordByNames         Procedure = "get_coin_records_by_names"
+	FullNodeCoinRecordByParentIds     Procedure = "get_coin_records_by_parent_ids"
+	FullNodeCoinRecordByHints         Procedure = "get_coin_records_by_hints"
+	FullNodeCoinRecordsByPuzzleHash   Procedure = "get_coin_records_by_puzzle_hash"
+	FullNodeCoinRecordsByPuzzleHashes Procedure = "get_coin_records_by_puzzle_hashes"
+	FullNodePushTx                    Procedure = "push_tx"
+
+	FullNodeGetBlockchainState         Procedure = "get_blockchain_state"
+	FullNodeGetBlock                   Procedure = "get_block"
+	FullNodeGetBlocks                  Procedure = "get_blocks"
+	FullNodeGetBlockCountMetrics       Procedure = "get_block_count_metrics"
+	FullNodeGetBlockRecordByHeight     Procedure = "get_block_record_by_height"
+	FullNodeGetBlockRecord             Procedure = "get_block_record"
+	FullNodeGetBlockRecords            Procedure = "get_block_records"
+	FullNodeGetBlockSpends             Procedure = "get_block_spends"
+	FullNodeGetUnfinishedBlockHeaders  Procedure = "get_unfinished_block_headers"
+	FullNodeGetNetworkSpace            Procedure = "get_network_space"
+	FullNodeGetAdditionsAndRemovals    Procedure = "get_additions_and_removals"
+	FullNodeGetPuzzleAndSolution       Procedure = "get_puzzle_and_solution"
+	FullNodeGetRecentSignagePointOrEOS Procedure = "get_recent_signage_point_or_eos"
+	FullNodeGetAllMempoolTxIds         Procedure = "get_all_mempool_tx_ids"
+	FullNodeGetAllMempoolItems         Procedure = "get_all_mempool_items"
+	FullNodeGetMempoolItemByTxId       Procedure = "get_mempool_item_by_tx_id"
+	FullNodeGetFeeEstimate             Procedure = "get_fee_estimate"
 )
 
-var (
-	FullNode *Endpoint = &Endpoint{Name: "full_node", Host: defaultHost, Port: 8555}
+// Procedures served by every Chia service.
+const (
+	GetRoutes Procedure = "get_routes"
+	Healthz   Procedure = "healthz"
 )
 
-func init() {
-	err := FullNode.Init()
-	if err != nil {
-		logErr.Panicln(err)
-	}
-}
-
-/*
-get_network_info
-get_blockchain_state
-get_block
-get_blocks
-get_block_count_metrics
-get_block_record_by_height
-get_block_record
-get_block_records
-get_block_spends
-get_unfinished_block_headers
-get_network_space
-get_additions_and_removals
-get_puzzle_and_solution
-get_recent_signage_point_or_eos
-get_coin_records_by_puzzle_hash
-get_coin_records_by_puzzle_hashes
-push_tx
-get_all_mempool_tx_ids
-get_all_mempool_items
-get_mempool_item_by_tx_id
-get_routes
-healthz
-*/
+var (
+	// FullNode is a convenience full node endpoint, configured and initialized on first use. See Endpoint.
+	FullNode *Endpoint = &Endpoint{Name: ServiceFullNode}
+)
 
 // Coin contains details about a specific coin.
 type Coin struct {
@@ -56,6 +56,32 @@ type Coin struct {
 	PuzzleHash     string `json:"puzzle_hash"`
 }
 
+// ID returns the coin's ID, or name, in hex with a 0x prefix; the SHA-256 hash of its parent coin info, puzzle hash and amount.
+func (c *Coin) ID() (string, error) {
+	parent, err := hex.DecodeString(strings.TrimPrefix(c.ParentCoinInfo, "0x"))
+	if err != nil || len(parent) != 32 {
+		return "", fmt.Errorf("Invalid parent coin info %q.", c.ParentCoinInfo)
+	}
+	ph, err := hex.DecodeString(strings.TrimPrefix(c.PuzzleHash, "0x"))
+	if err != nil || len(ph) != 32 {
+		return "", fmt.Errorf("Invalid puzzle hash %q.", c.PuzzleHash)
+	}
+	h := sha256.New()
+	h.Write(parent)
+	h.Write(ph)
+	h.Write(amountBytes(uint64(c.Amount)))
+	return "0x" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// amountBytes returns a as Chia serializes integers for hashing; big-endian, in as few bytes as hold it as a signed integer, and no bytes for zero.
+func amountBytes(a uint64) []byte {
+	b := binary.BigEndian.AppendUint64([]byte{0}, a)
+	for len(b) > 0 && b[0] == 0 && (len(b) == 1 || b[1] < 0x80) {
+		b = b[1:]
+	}
+	return b
+}
+
 // CoinRecord contains details about a coin record.
 type CoinRecord struct {
 	Coin                *Coin `json:"coin"`
@@ -69,8 +95,7 @@ type CoinRecord struct {
 // CoinRecordResponse represents the Chia RPC API's response to a CoinRecordRequest.
 type CoinRecordResponse struct {
 	CoinRecord *CoinRecord `json:"coin_record"`
-	Success    bool        `json:"success"`
-	Error      string      `json:"error"`
+	Response
 }
 
 // CoinRecordRequest is a type for making a request for a single CoinRecord by name.
@@ -85,45 +110,26 @@ func (c *CoinRecordRequest) Procedure() Procedure {
 
 // Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (c *CoinRecordRequest) Send(e *Endpoint) (*CoinRecordResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(c.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	cr := new(CoinRecordResponse)
-	err = json.Unmarshal(out, cr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return cr, nil
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordRequest) SendContext(ctx context.Context, caller Caller) (*CoinRecordResponse, error) {
+	return Do[*CoinRecordRequest, CoinRecordResponse](ctx, caller, c)
 }
 
 // String implements the fmt.Stringer interface.
 func (c *CoinRecordRequest) String() string {
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, c.Procedure(), j)
+	return requestString(c)
 }
 
 // CoinRecordsResponse represents the Chia RPC API's response to a CoinRecordRequest.
 type CoinRecordsResponse struct {
 	CoinRecords []*CoinRecord `json:"coin_records"`
-	Success     bool          `json:"success"`
-	Error       string        `json:"error"`
+	Response
 }
 
-// CoinRecordsRequest is a type for making at least one request for a multiple CoinRecords by coin names, parent ids, and/or hints.
+// CoinRecordsRequest is a composite query for CoinRecords by coin names, parent ids, and/or hints. Only the procedures whose inputs are set are called, concurrently, and their CoinRecords are merged, without duplicates.
 type CoinRecordsRequest struct {
 	Names        []string `json:"names"`
 	ParentIds    []string `json:"parent_ids"`
@@ -138,89 +144,119 @@ func (c *CoinRecordsRequest) Procedure() Procedure {
 	return FullNodeCoinRecordByNames
 }
 
-// Sends at least one request, of at least one CoinRecordsByN procedure, via an Endpoint, and returns the response, and any error. If successful, error returns nil.
+// Sends the request via an Endpoint, and returns the response, and any error. If successful, error returns nil.
 func (c *CoinRecordsRequest) Send(e *Endpoint) (*CoinRecordsResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends a request for each of Names, ParentIds and Hints which is set, concurrently, via a Caller, with a Context, and returns the merged response, and an error. If successful, error returns nil.
+// If only some of the requests fail, the CoinRecords of the others are returned, along with a *CoinRecordsError reporting the failures.
+func (c *CoinRecordsRequest) SendContext(ctx context.Context, caller Caller) (*CoinRecordsResponse, error) {
+	type query func() (*CoinRecordsResponse, error)
+	queries := make(map[Procedure]query)
+	if len(c.Names) > 0 {
+		r := &CoinRecordsByNameRequest{Names: c.Names, StartHeight: c.StartHeight, EndHeight: c.EndHeight, IncludeSpent: c.IncludeSpent}
+		queries[r.Procedure()] = func() (*CoinRecordsResponse, error) { return r.SendContext(ctx, caller) }
 	}
-	// Make request(s)
-	responses := make([][]byte, 0)
-	switch {
-	case len(c.Names) > 0:
-		// Make request with Names
-		out, err := e.Call(c.Procedure(), j)
-		if err != nil {
-			logErr.Println(err)
-			return nil, err
-		}
-		responses = append(responses, out)
-		fallthrough
-	case len(c.ParentIds) > 0:
-		// Make request with ParentIds
-		out, err := e.Call(FullNodeCoinRecordByParentIds, j)
-		if err != nil {
-			logErr.Println(err)
-			return nil, err
-		}
-		responses = append(responses, out)
-		fallthrough
-	case len(c.Hints) > 0:
-		// Make request with Hints
-		out, err := e.Call(FullNodeCoinRecordByHints, j)
-		if err != nil {
-			logErr.Println(err)
-			return nil, err
-		}
-		responses = append(responses, out)
-	default:
+	if len(c.ParentIds) > 0 {
+		r := &CoinRecordsByParentIdsRequest{ParentIds: c.ParentIds, StartHeight: c.StartHeight, EndHeight: c.EndHeight, IncludeSpent: c.IncludeSpent}
+		queries[r.Procedure()] = func() (*CoinRecordsResponse, error) { return r.SendContext(ctx, caller) }
+	}
+	if len(c.Hints) > 0 {
+		r := &CoinRecordsByHintsRequest{Hints: c.Hints, StartHeight: c.StartHeight, EndHeight: c.EndHeight, IncludeSpent: c.IncludeSpent}
+		queries[r.Procedure()] = func() (*CoinRecordsResponse, error) { return r.SendContext(ctx, caller) }
+	}
+	if len(queries) == 0 {
 		// Nothing to request.
-		err = fmt.Errorf("Failed to make CoinRecords request, please set Names, ParentIds, or Hints.")
-		logErr.Println(err)
-
-		return nil, err
+		return nil, fmt.Errorf("Failed to make CoinRecords request, please set Names, ParentIds, or Hints.")
+	}
+	// Make requests
+	type result struct {
+		p   Procedure
+		r   *CoinRecordsResponse
+		err error
+	}
+	results := make(chan result, len(queries))
+	for p, q := range queries {
+		go func() {
+			r, err := q()
+			results <- result{p, r, err}
+		}()
+	}
+	// Merge responses, in a fixed order, whichever finishes first.
+	byProcedure := make(map[Procedure]result, len(queries))
+	for range queries {
+		r := <-results
+		byProcedure[r.p] = r
 	}
-	// Handle can consolidate response(s)
-	errs := NewErrors()
 	cr := new(CoinRecordsResponse)
-	for _, rout := range responses {
-		// Handle response
-		tempCr := new(CoinRecordsResponse)
-		err = json.Unmarshal(rout, tempCr)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("CoinRecordsRequest failed to unmarshal response. Error: %s", err))
+	cerr := &CoinRecordsError{Errors: make(map[Procedure]error)}
+	seen := make(map[string]bool)
+	for _, p := range []Procedure{FullNodeCoinRecordByNames, FullNodeCoinRecordByParentIds, FullNodeCoinRecordByHints} {
+		r, ok := byProcedure[p]
+		if !ok {
 			continue
 		}
-		if !tempCr.Success {
-			errs = append(errs, fmt.Errorf("CoinRecordsRequest was unsuccessful. Error: %s", tempCr.Error))
+		if r.err != nil {
+			cerr.Errors[p] = r.err
 			continue
 		}
 		cr.Success = true
-		cr.CoinRecords = append(cr.CoinRecords, tempCr.CoinRecords...)
+		for _, rec := range r.r.CoinRecords {
+			// Records without a valid coin can't be identified, so are kept as they are.
+			if rec.Coin != nil {
+				if id, err := rec.Coin.ID(); err == nil {
+					if seen[id] {
+						continue
+					}
+					seen[id] = true
+				}
+			}
+			cr.CoinRecords = append(cr.CoinRecords, rec)
+		}
 	}
-	if len(errs) > 0 {
-		err = fmt.Errorf("%s \n", errs)
-		logErr.Println(err)
-		return cr, errs
+	if len(cerr.Errors) > 0 {
+		cr.Error = cerr.Error()
+		return cr, cerr
 	}
-
-	return cr, err
+	return cr, nil
 }
 
 // String implements the fmt.Stringer interface.
 func (c *CoinRecordsRequest) String() string {
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
+	return requestString(c)
+}
+
+// A CoinRecordsError reports which requests of a CoinRecordsRequest failed, and why.
+type CoinRecordsError struct {
+	Errors map[Procedure]error
+}
+
+// Error implements the built-in error interface.
+func (e *CoinRecordsError) Error() string {
+	ps := make([]string, 0, len(e.Errors))
+	for p := range e.Errors {
+		ps = append(ps, string(p))
+	}
+	sort.Strings(ps)
+	for i, p := range ps {
+		ps[i] = fmt.Sprintf("%s: %s", p, e.Errors[Procedure(p)])
+	}
+	return "CoinRecordsRequest failed; " + strings.Join(ps, "; ")
+}
+
+// Unwrap returns the errors of the failed requests, for use with errors.Is and errors.As.
+func (e *CoinRecordsError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
 	}
-	return fmt.Sprintf(`%s %q`, c.Procedure(), j)
+	return errs
 }
 
 // CoinRecordsByNameRequest is a type for making a request for a multiple CoinRecords by coin name.
 type CoinRecordsByNameRequest struct {
-	Name         []string `json:"parent_ids"`
+	Names        []string `json:"names"`
 	StartHeight  uint     `json:"start_height,omitempty"`
 	EndHeight    uint     `json:"end_height,omitempty"`
 	IncludeSpent bool     `json:"include_spent_coins,omitempty"`
@@ -228,40 +264,50 @@ type CoinRecordsByNameRequest struct {
 
 // Procedure returns the Procedure which this request will use.
 func (c *CoinRecordsByNameRequest) Procedure() Procedure {
-	return FullNodeCoinRecordByName
+	return FullNodeCoinRecordByNames
 }
 
 // Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (c *CoinRecordsByNameRequest) Send(e *Endpoint) (*CoinRecordsResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(c.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	cr := new(CoinRecordsResponse)
-	err = json.Unmarshal(out, cr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return cr, nil
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByNameRequest) SendContext(ctx context.Context, caller Caller) (*CoinRecordsResponse, error) {
+	return Do[*CoinRecordsByNameRequest, CoinRecordsResponse](ctx, caller, c)
 }
 
 // String implements the fmt.Stringer interface.
 func (c *CoinRecordsByNameRequest) String() string {
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, c.Procedure(), j)
+	return requestString(c)
+}
+
+// CoinRecordsByHintsRequest is a type for making a request for a multiple CoinRecords by hints.
+type CoinRecordsByHintsRequest struct {
+	Hints        []string `json:"hints"`
+	StartHeight  uint     `json:"start_height,omitempty"`
+	EndHeight    uint     `json:"end_height,omitempty"`
+	IncludeSpent bool     `json:"include_spent_coins,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CoinRecordsByHintsRequest) Procedure() Procedure {
+	return FullNodeCoinRecordByHints
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByHintsRequest) Send(e *Endpoint) (*CoinRecordsResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByHintsRequest) SendContext(ctx context.Context, caller Caller) (*CoinRecordsResponse, error) {
+	return Do[*CoinRecordsByHintsRequest, CoinRecordsResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CoinRecordsByHintsRequest) String() string {
+	return requestString(c)
 }
 
 // CoinRecordsByParentIdsRequest is a type for making a request for a multiple CoinRecords by parent ids.
@@ -279,42 +325,81 @@ func (c *CoinRecordsByParentIdsRequest) Procedure() Procedure {
 
 // Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (c *CoinRecordsByParentIdsRequest) Send(e *Endpoint) (*CoinRecordsResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(c.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	cr := new(CoinRecordsResponse)
-	err = json.Unmarshal(out, cr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return cr, nil
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByParentIdsRequest) SendContext(ctx context.Context, caller Caller) (*CoinRecordsResponse, error) {
+	return Do[*CoinRecordsByParentIdsRequest, CoinRecordsResponse](ctx, caller, c)
 }
 
 // String implements the fmt.Stringer interface.
 func (c *CoinRecordsByParentIdsRequest) String() string {
-	j, err := json.Marshal(c)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, c.Procedure(), j)
+	return requestString(c)
+}
+
+// CoinRecordsByPuzzleHashRequest is a type for making a request for multiple CoinRecords by puzzle hash, confirmed from StartHeight, up to but excluding EndHeight, if set.
+// For heavily used puzzle hashes, see CoinRecordPager.
+type CoinRecordsByPuzzleHashRequest struct {
+	PuzzleHash   string `json:"puzzle_hash"`
+	StartHeight  uint   `json:"start_height,omitempty"`
+	EndHeight    uint   `json:"end_height,omitempty"`
+	IncludeSpent bool   `json:"include_spent_coins,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CoinRecordsByPuzzleHashRequest) Procedure() Procedure {
+	return FullNodeCoinRecordsByPuzzleHash
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByPuzzleHashRequest) Send(e *Endpoint) (*CoinRecordsResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByPuzzleHashRequest) SendContext(ctx context.Context, caller Caller) (*CoinRecordsResponse, error) {
+	return Do[*CoinRecordsByPuzzleHashRequest, CoinRecordsResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CoinRecordsByPuzzleHashRequest) String() string {
+	return requestString(c)
+}
+
+// CoinRecordsByPuzzleHashesRequest is a type for making a request for multiple CoinRecords by puzzle hashes, confirmed from StartHeight, up to but excluding EndHeight, if set.
+// For heavily used puzzle hashes, see CoinRecordPager.
+type CoinRecordsByPuzzleHashesRequest struct {
+	PuzzleHashes []string `json:"puzzle_hashes"`
+	StartHeight  uint     `json:"start_height,omitempty"`
+	EndHeight    uint     `json:"end_height,omitempty"`
+	IncludeSpent bool     `json:"include_spent_coins,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CoinRecordsByPuzzleHashesRequest) Procedure() Procedure {
+	return FullNodeCoinRecordsByPuzzleHashes
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByPuzzleHashesRequest) Send(e *Endpoint) (*CoinRecordsResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CoinRecordsByPuzzleHashesRequest) SendContext(ctx context.Context, caller Caller) (*CoinRecordsResponse, error) {
+	return Do[*CoinRecordsByPuzzleHashesRequest, CoinRecordsResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CoinRecordsByPuzzleHashesRequest) String() string {
+	return requestString(c)
 }
 
 // PushTxResponse represents the Chia RPC API's response to a PushTxRequest.
 type PushTxResponse struct {
-	Status  string `json:"status"`
-	Success bool   `json:"success"`
-	Error   string `json:"error"`
+	Status string `json:"status"`
+	Response
 }
 
 // PushTxRequest is a type for making a request to submit a SpendBundle to the blockchain.
@@ -329,33 +414,15 @@ func (c *PushTxRequest) Procedure() Procedure {
 
 // Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (p *PushTxRequest) Send(e *Endpoint) (*PushTxResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(p)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(p.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	pr := new(PushTxResponse)
-	err = json.Unmarshal(out, pr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return pr, nil
+	return p.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (p *PushTxRequest) SendContext(ctx context.Context, caller Caller) (*PushTxResponse, error) {
+	return Do[*PushTxRequest, PushTxResponse](ctx, caller, p)
 }
 
 // String implements the fmt.Stringer interface.
 func (p *PushTxRequest) String() string {
-	j, err := json.Marshal(p)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, p.Procedure(), j)
+	return requestString(p)
 }
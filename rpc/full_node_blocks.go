@@ -0,0 +1,779 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+)
+
+// ClassgroupElement is the output of a VDF.
+type ClassgroupElement struct {
+	Data string `json:"data"`
+}
+
+// VDFInfo describes a verifiable delay function evaluation.
+type VDFInfo struct {
+	Challenge          string             `json:"challenge"`
+	NumberOfIterations uint               `json:"number_of_iterations"`
+	Output             *ClassgroupElement `json:"output"`
+}
+
+// VDFProof is the proof of a VDF evaluation.
+type VDFProof struct {
+	WitnessType          uint   `json:"witness_type"`
+	Witness              string `json:"witness"`
+	NormalizedToIdentity bool   `json:"normalized_to_identity"`
+}
+
+// ProofOfSpace is the proof of space of a block, from a farmer's plot.
+type ProofOfSpace struct {
+	Challenge              string  `json:"challenge"`
+	PoolPublicKey          *string `json:"pool_public_key"`           // Set for plots farmed without a pool contract.
+	PoolContractPuzzleHash *string `json:"pool_contract_puzzle_hash"` // Set for plots farmed with a pool contract.
+	PlotPublicKey          string  `json:"plot_public_key"`
+	Size                   uint    `json:"size"`
+	Proof                  string  `json:"proof"`
+}
+
+// RewardChainBlock is the reward chain part of a block. The infusion point VDFs aren't set for unfinished blocks.
+type RewardChainBlock struct {
+	Weight                     *big.Int      `json:"weight,omitempty"`
+	Height                     uint          `json:"height"`
+	TotalIters                 *big.Int      `json:"total_iters"`
+	SignagePointIndex          uint          `json:"signage_point_index"`
+	PosSsCcChallengeHash       string        `json:"pos_ss_cc_challenge_hash"`
+	ProofOfSpace               *ProofOfSpace `json:"proof_of_space"`
+	ChallengeChainSpVdf        *VDFInfo      `json:"challenge_chain_sp_vdf"`
+	ChallengeChainSpSignature  string        `json:"challenge_chain_sp_signature"`
+	ChallengeChainIpVdf        *VDFInfo      `json:"challenge_chain_ip_vdf,omitempty"`
+	RewardChainSpVdf           *VDFInfo      `json:"reward_chain_sp_vdf"`
+	RewardChainSpSignature     string        `json:"reward_chain_sp_signature"`
+	RewardChainIpVdf           *VDFInfo      `json:"reward_chain_ip_vdf,omitempty"`
+	InfusedChallengeChainIpVdf *VDFInfo      `json:"infused_challenge_chain_ip_vdf,omitempty"`
+	IsTransactionBlock         bool          `json:"is_transaction_block"`
+}
+
+// PoolTarget is the puzzle hash to which a block's pool reward is paid.
+type PoolTarget struct {
+	PuzzleHash string `json:"puzzle_hash"`
+	MaxHeight  uint   `json:"max_height"`
+}
+
+// FoliageBlockData is the signed data of a block's foliage.
+type FoliageBlockData struct {
+	UnfinishedRewardBlockHash string      `json:"unfinished_reward_block_hash"`
+	PoolTarget                *PoolTarget `json:"pool_target"`
+	PoolSignature             *string     `json:"pool_signature"`
+	FarmerRewardPuzzleHash    string      `json:"farmer_reward_puzzle_hash"`
+	ExtensionData             string      `json:"extension_data"`
+}
+
+// Foliage is the part of a block which isn't part of the proof of space and time, and links blocks together.
+type Foliage struct {
+	PrevBlockHash                    string            `json:"prev_block_hash"`
+	RewardBlockHash                  string            `json:"reward_block_hash"`
+	FoliageBlockData                 *FoliageBlockData `json:"foliage_block_data"`
+	FoliageBlockDataSignature        string            `json:"foliage_block_data_signature"`
+	FoliageTransactionBlockHash      *string           `json:"foliage_transaction_block_hash"`
+	FoliageTransactionBlockSignature *string           `json:"foliage_transaction_block_signature"`
+}
+
+// FoliageTransactionBlock is the foliage of a transaction block.
+type FoliageTransactionBlock struct {
+	PrevTransactionBlockHash string `json:"prev_transaction_block_hash"`
+	Timestamp                uint   `json:"timestamp"`
+	FilterHash               string `json:"filter_hash"`
+	AdditionsRoot            string `json:"additions_root"`
+	RemovalsRoot             string `json:"removals_root"`
+	TransactionsInfoHash     string `json:"transactions_info_hash"`
+}
+
+// TransactionsInfo describes the transactions of a transaction block.
+type TransactionsInfo struct {
+	GeneratorRoot            string  `json:"generator_root"`
+	GeneratorRefsRoot        string  `json:"generator_refs_root"`
+	AggregatedSignature      string  `json:"aggregated_signature"`
+	Fees                     uint    `json:"fees"`
+	Cost                     uint    `json:"cost"`
+	RewardClaimsIncorporated []*Coin `json:"reward_claims_incorporated"`
+}
+
+// FullBlock is a complete block, as stored by the full node. The finished sub-slots are left as raw JSON.
+type FullBlock struct {
+	HeaderHash                   string                   `json:"header_hash,omitempty"` // Only set by get_blocks, unless excluded.
+	FinishedSubSlots             []json.RawMessage        `json:"finished_sub_slots"`
+	RewardChainBlock             *RewardChainBlock        `json:"reward_chain_block"`
+	ChallengeChainSpProof        *VDFProof                `json:"challenge_chain_sp_proof"`
+	ChallengeChainIpProof        *VDFProof                `json:"challenge_chain_ip_proof"`
+	RewardChainSpProof           *VDFProof                `json:"reward_chain_sp_proof"`
+	RewardChainIpProof           *VDFProof                `json:"reward_chain_ip_proof"`
+	InfusedChallengeChainIpProof *VDFProof                `json:"infused_challenge_chain_ip_proof"`
+	Foliage                      *Foliage                 `json:"foliage"`
+	FoliageTransactionBlock      *FoliageTransactionBlock `json:"foliage_transaction_block"` // Nil unless a transaction block.
+	TransactionsInfo             *TransactionsInfo        `json:"transactions_info"`         // Nil unless a transaction block.
+	TransactionsGenerator        *string                  `json:"transactions_generator"`
+	TransactionsGeneratorRefList []uint                   `json:"transactions_generator_ref_list"`
+}
+
+// UnfinishedHeaderBlock is the header of a block which hasn't been infused yet.
+type UnfinishedHeaderBlock struct {
+	FinishedSubSlots        []json.RawMessage        `json:"finished_sub_slots"`
+	RewardChainBlock        *RewardChainBlock        `json:"reward_chain_block"`
+	ChallengeChainSpProof   *VDFProof                `json:"challenge_chain_sp_proof"`
+	RewardChainSpProof      *VDFProof                `json:"reward_chain_sp_proof"`
+	Foliage                 *Foliage                 `json:"foliage"`
+	FoliageTransactionBlock *FoliageTransactionBlock `json:"foliage_transaction_block"`
+	TransactionsFilter      string                   `json:"transactions_filter"`
+}
+
+// BlockRecord is the summary of a block which the full node keeps for every block of its chain.
+type BlockRecord struct {
+	HeaderHash                         string             `json:"header_hash"`
+	PrevHash                           string             `json:"prev_hash"`
+	Height                             uint               `json:"height"`
+	Weight                             *big.Int           `json:"weight"`
+	TotalIters                         *big.Int           `json:"total_iters"`
+	SignagePointIndex                  uint               `json:"signage_point_index"`
+	ChallengeVdfOutput                 *ClassgroupElement `json:"challenge_vdf_output"`
+	InfusedChallengeVdfOutput          *ClassgroupElement `json:"infused_challenge_vdf_output"`
+	RewardInfusionNewChallenge         string             `json:"reward_infusion_new_challenge"`
+	ChallengeBlockInfoHash             string             `json:"challenge_block_info_hash"`
+	SubSlotIters                       uint               `json:"sub_slot_iters"`
+	PoolPuzzleHash                     string             `json:"pool_puzzle_hash"`
+	FarmerPuzzleHash                   string             `json:"farmer_puzzle_hash"`
+	RequiredIters                      uint               `json:"required_iters"`
+	Deficit                            uint               `json:"deficit"`
+	Overflow                           bool               `json:"overflow"`
+	PrevTransactionBlockHeight         uint               `json:"prev_transaction_block_height"`
+	Timestamp                          *uint              `json:"timestamp"`                   // Nil unless a transaction block.
+	PrevTransactionBlockHash           *string            `json:"prev_transaction_block_hash"` // Nil unless a transaction block.
+	Fees                               *uint              `json:"fees"`                        // Nil unless a transaction block.
+	RewardClaimsIncorporated           []*Coin            `json:"reward_claims_incorporated"`
+	FinishedChallengeSlotHashes        []string           `json:"finished_challenge_slot_hashes"`
+	FinishedInfusedChallengeSlotHashes []string           `json:"finished_infused_challenge_slot_hashes"`
+	FinishedRewardSlotHashes           []string           `json:"finished_reward_slot_hashes"`
+	SubEpochSummaryIncluded            json.RawMessage    `json:"sub_epoch_summary_included"`
+}
+
+// IsTransactionBlock reports whether the block is a transaction block.
+func (b *BlockRecord) IsTransactionBlock() bool {
+	return b.Timestamp != nil
+}
+
+// SyncState is the sync state of a full node.
+type SyncState struct {
+	SyncMode           bool `json:"sync_mode"`
+	Synced             bool `json:"synced"`
+	SyncTipHeight      uint `json:"sync_tip_height"`
+	SyncProgressHeight uint `json:"sync_progress_height"`
+}
+
+// BlockchainState is the state of the blockchain, as seen by a full node.
+type BlockchainState struct {
+	Peak                        *BlockRecord       `json:"peak"` // Nil until the node has a chain.
+	GenesisChallengeInitialized bool               `json:"genesis_challenge_initialized"`
+	Sync                        *SyncState         `json:"sync"`
+	Difficulty                  uint               `json:"difficulty"`
+	SubSlotIters                uint               `json:"sub_slot_iters"`
+	Space                       *big.Int           `json:"space"`
+	AverageBlockTime            uint               `json:"average_block_time,omitempty"` // Reported by recent versions of Chia only.
+	MempoolSize                 uint               `json:"mempool_size"`
+	MempoolCost                 uint               `json:"mempool_cost"`
+	MempoolFees                 uint               `json:"mempool_fees"`
+	MempoolMinFees              map[string]float64 `json:"mempool_min_fees"` // Minimum fee rates, in mojos per cost, keyed by cost, such as "cost_5000000".
+	MempoolMaxTotalCost         uint               `json:"mempool_max_total_cost"`
+	BlockMaxCost                uint               `json:"block_max_cost"`
+	NodeId                      string             `json:"node_id"`
+}
+
+// BlockCountMetrics are counts kept by the full node's block store.
+type BlockCountMetrics struct {
+	CompactBlocks   uint `json:"compact_blocks"`
+	UncompactBlocks uint `json:"uncompact_blocks"`
+	HintCount       uint `json:"hint_count"`
+}
+
+// NPCResult is the result of running a spend bundle's puzzles. The conditions are left as raw JSON.
+type NPCResult struct {
+	Error *uint           `json:"error"`
+	Cost  uint            `json:"cost"`
+	Conds json.RawMessage `json:"conds"`
+}
+
+// MempoolItem is a spend bundle waiting in the mempool.
+type MempoolItem struct {
+	SpendBundle          *SpendBundle `json:"spend_bundle"`
+	Fee                  uint         `json:"fee"`
+	NPCResult            *NPCResult   `json:"npc_result"`
+	Cost                 uint         `json:"cost"`
+	SpendBundleName      string       `json:"spend_bundle_name"`
+	Additions            []*Coin      `json:"additions"`
+	Removals             []*Coin      `json:"removals"`
+	HeightAddedToMempool uint         `json:"height_added_to_mempool"`
+	AssertHeight         *uint        `json:"assert_height,omitempty"`
+	AssertBeforeHeight   *uint        `json:"assert_before_height,omitempty"`
+	AssertBeforeSeconds  *uint        `json:"assert_before_seconds,omitempty"`
+}
+
+// FeeRate returns the item's fee per unit of cost, in mojos. It is zero if the item has no cost.
+func (m *MempoolItem) FeeRate() float64 {
+	if m.Cost == 0 {
+		return 0
+	}
+	return float64(m.Fee) / float64(m.Cost)
+}
+
+// BlockchainStateResponse represents the Chia RPC API's response to a BlockchainStateRequest.
+type BlockchainStateResponse struct {
+	BlockchainState *BlockchainState `json:"blockchain_state"`
+	Response
+}
+
+// BlockchainStateRequest is a type for making a request for the current state of the blockchain.
+type BlockchainStateRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlockchainStateRequest) Procedure() Procedure {
+	return FullNodeGetBlockchainState
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockchainStateRequest) Send(e *Endpoint) (*BlockchainStateResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockchainStateRequest) SendContext(ctx context.Context, caller Caller) (*BlockchainStateResponse, error) {
+	return Do[*BlockchainStateRequest, BlockchainStateResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlockchainStateRequest) String() string {
+	return requestString(b)
+}
+
+// BlockResponse represents the Chia RPC API's response to a BlockRequest.
+type BlockResponse struct {
+	Block *FullBlock `json:"block"`
+	Response
+}
+
+// BlockRequest is a type for making a request for a FullBlock by header hash.
+type BlockRequest struct {
+	HeaderHash string `json:"header_hash"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlockRequest) Procedure() Procedure {
+	return FullNodeGetBlock
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRequest) Send(e *Endpoint) (*BlockResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRequest) SendContext(ctx context.Context, caller Caller) (*BlockResponse, error) {
+	return Do[*BlockRequest, BlockResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlockRequest) String() string {
+	return requestString(b)
+}
+
+// BlocksResponse represents the Chia RPC API's response to a BlocksRequest.
+type BlocksResponse struct {
+	Blocks []*FullBlock `json:"blocks"`
+	Response
+}
+
+// BlocksRequest is a type for making a request for the FullBlocks in a range of heights, from Start, up to but excluding End.
+type BlocksRequest struct {
+	Start             uint `json:"start"`
+	End               uint `json:"end"`
+	ExcludeHeaderHash bool `json:"exclude_header_hash,omitempty"`
+	ExcludeReorged    bool `json:"exclude_reorged,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlocksRequest) Procedure() Procedure {
+	return FullNodeGetBlocks
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlocksRequest) Send(e *Endpoint) (*BlocksResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlocksRequest) SendContext(ctx context.Context, caller Caller) (*BlocksResponse, error) {
+	return Do[*BlocksRequest, BlocksResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlocksRequest) String() string {
+	return requestString(b)
+}
+
+// BlockCountMetricsResponse represents the Chia RPC API's response to a BlockCountMetricsRequest.
+type BlockCountMetricsResponse struct {
+	Metrics *BlockCountMetrics `json:"metrics"`
+	Response
+}
+
+// BlockCountMetricsRequest is a type for making a request for counts of compact and uncompact blocks, and of hints.
+type BlockCountMetricsRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlockCountMetricsRequest) Procedure() Procedure {
+	return FullNodeGetBlockCountMetrics
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockCountMetricsRequest) Send(e *Endpoint) (*BlockCountMetricsResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockCountMetricsRequest) SendContext(ctx context.Context, caller Caller) (*BlockCountMetricsResponse, error) {
+	return Do[*BlockCountMetricsRequest, BlockCountMetricsResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlockCountMetricsRequest) String() string {
+	return requestString(b)
+}
+
+// BlockRecordByHeightResponse represents the Chia RPC API's response to a BlockRecordByHeightRequest.
+type BlockRecordByHeightResponse struct {
+	BlockRecord *BlockRecord `json:"block_record"`
+	Response
+}
+
+// BlockRecordByHeightRequest is a type for making a request for the BlockRecord of the peak chain at a height.
+type BlockRecordByHeightRequest struct {
+	Height uint `json:"height"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlockRecordByHeightRequest) Procedure() Procedure {
+	return FullNodeGetBlockRecordByHeight
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRecordByHeightRequest) Send(e *Endpoint) (*BlockRecordByHeightResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRecordByHeightRequest) SendContext(ctx context.Context, caller Caller) (*BlockRecordByHeightResponse, error) {
+	return Do[*BlockRecordByHeightRequest, BlockRecordByHeightResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlockRecordByHeightRequest) String() string {
+	return requestString(b)
+}
+
+// BlockRecordResponse represents the Chia RPC API's response to a BlockRecordRequest.
+type BlockRecordResponse struct {
+	BlockRecord *BlockRecord `json:"block_record"`
+	Response
+}
+
+// BlockRecordRequest is a type for making a request for a BlockRecord by header hash.
+type BlockRecordRequest struct {
+	HeaderHash string `json:"header_hash"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlockRecordRequest) Procedure() Procedure {
+	return FullNodeGetBlockRecord
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRecordRequest) Send(e *Endpoint) (*BlockRecordResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRecordRequest) SendContext(ctx context.Context, caller Caller) (*BlockRecordResponse, error) {
+	return Do[*BlockRecordRequest, BlockRecordResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlockRecordRequest) String() string {
+	return requestString(b)
+}
+
+// BlockRecordsResponse represents the Chia RPC API's response to a BlockRecordsRequest.
+type BlockRecordsResponse struct {
+	BlockRecords []*BlockRecord `json:"block_records"`
+	Response
+}
+
+// BlockRecordsRequest is a type for making a request for the BlockRecords in a range of heights, from Start, up to but excluding End.
+type BlockRecordsRequest struct {
+	Start uint `json:"start"`
+	End   uint `json:"end"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlockRecordsRequest) Procedure() Procedure {
+	return FullNodeGetBlockRecords
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRecordsRequest) Send(e *Endpoint) (*BlockRecordsResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockRecordsRequest) SendContext(ctx context.Context, caller Caller) (*BlockRecordsResponse, error) {
+	return Do[*BlockRecordsRequest, BlockRecordsResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlockRecordsRequest) String() string {
+	return requestString(b)
+}
+
+// BlockSpendsResponse represents the Chia RPC API's response to a BlockSpendsRequest.
+type BlockSpendsResponse struct {
+	BlockSpends []*Solution `json:"block_spends"`
+	Response
+}
+
+// BlockSpendsRequest is a type for making a request for the coin spends of a block, by header hash.
+type BlockSpendsRequest struct {
+	HeaderHash string `json:"header_hash"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (b *BlockSpendsRequest) Procedure() Procedure {
+	return FullNodeGetBlockSpends
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockSpendsRequest) Send(e *Endpoint) (*BlockSpendsResponse, error) {
+	return b.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (b *BlockSpendsRequest) SendContext(ctx context.Context, caller Caller) (*BlockSpendsResponse, error) {
+	return Do[*BlockSpendsRequest, BlockSpendsResponse](ctx, caller, b)
+}
+
+// String implements the fmt.Stringer interface.
+func (b *BlockSpendsRequest) String() string {
+	return requestString(b)
+}
+
+// UnfinishedBlockHeadersResponse represents the Chia RPC API's response to a UnfinishedBlockHeadersRequest.
+type UnfinishedBlockHeadersResponse struct {
+	Headers []*UnfinishedHeaderBlock `json:"headers"`
+	Response
+}
+
+// UnfinishedBlockHeadersRequest is a type for making a request for the headers of the unfinished blocks at the peak.
+type UnfinishedBlockHeadersRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (u *UnfinishedBlockHeadersRequest) Procedure() Procedure {
+	return FullNodeGetUnfinishedBlockHeaders
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (u *UnfinishedBlockHeadersRequest) Send(e *Endpoint) (*UnfinishedBlockHeadersResponse, error) {
+	return u.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (u *UnfinishedBlockHeadersRequest) SendContext(ctx context.Context, caller Caller) (*UnfinishedBlockHeadersResponse, error) {
+	return Do[*UnfinishedBlockHeadersRequest, UnfinishedBlockHeadersResponse](ctx, caller, u)
+}
+
+// String implements the fmt.Stringer interface.
+func (u *UnfinishedBlockHeadersRequest) String() string {
+	return requestString(u)
+}
+
+// NetworkSpaceResponse represents the Chia RPC API's response to a NetworkSpaceRequest.
+type NetworkSpaceResponse struct {
+	Space *big.Int `json:"space"`
+	Response
+}
+
+// NetworkSpaceRequest is a type for making a request for the estimated network space, in bytes, between two blocks.
+type NetworkSpaceRequest struct {
+	NewerBlockHeaderHash string `json:"newer_block_header_hash"`
+	OlderBlockHeaderHash string `json:"older_block_header_hash"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (n *NetworkSpaceRequest) Procedure() Procedure {
+	return FullNodeGetNetworkSpace
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (n *NetworkSpaceRequest) Send(e *Endpoint) (*NetworkSpaceResponse, error) {
+	return n.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (n *NetworkSpaceRequest) SendContext(ctx context.Context, caller Caller) (*NetworkSpaceResponse, error) {
+	return Do[*NetworkSpaceRequest, NetworkSpaceResponse](ctx, caller, n)
+}
+
+// String implements the fmt.Stringer interface.
+func (n *NetworkSpaceRequest) String() string {
+	return requestString(n)
+}
+
+// AdditionsAndRemovalsResponse represents the Chia RPC API's response to a AdditionsAndRemovalsRequest.
+type AdditionsAndRemovalsResponse struct {
+	Additions []*CoinRecord `json:"additions"`
+	Removals  []*CoinRecord `json:"removals"`
+	Response
+}
+
+// AdditionsAndRemovalsRequest is a type for making a request for the coins created and spent by a block, by header hash.
+type AdditionsAndRemovalsRequest struct {
+	HeaderHash string `json:"header_hash"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (a *AdditionsAndRemovalsRequest) Procedure() Procedure {
+	return FullNodeGetAdditionsAndRemovals
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (a *AdditionsAndRemovalsRequest) Send(e *Endpoint) (*AdditionsAndRemovalsResponse, error) {
+	return a.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (a *AdditionsAndRemovalsRequest) SendContext(ctx context.Context, caller Caller) (*AdditionsAndRemovalsResponse, error) {
+	return Do[*AdditionsAndRemovalsRequest, AdditionsAndRemovalsResponse](ctx, caller, a)
+}
+
+// String implements the fmt.Stringer interface.
+func (a *AdditionsAndRemovalsRequest) String() string {
+	return requestString(a)
+}
+
+// PuzzleAndSolutionResponse represents the Chia RPC API's response to a PuzzleAndSolutionRequest.
+type PuzzleAndSolutionResponse struct {
+	CoinSolution *Solution `json:"coin_solution"`
+	Response
+}
+
+// PuzzleAndSolutionRequest is a type for making a request for the puzzle reveal and solution of a coin, spent at a height.
+type PuzzleAndSolutionRequest struct {
+	CoinId string `json:"coin_id"`
+	Height uint   `json:"height"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (p *PuzzleAndSolutionRequest) Procedure() Procedure {
+	return FullNodeGetPuzzleAndSolution
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (p *PuzzleAndSolutionRequest) Send(e *Endpoint) (*PuzzleAndSolutionResponse, error) {
+	return p.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (p *PuzzleAndSolutionRequest) SendContext(ctx context.Context, caller Caller) (*PuzzleAndSolutionResponse, error) {
+	return Do[*PuzzleAndSolutionRequest, PuzzleAndSolutionResponse](ctx, caller, p)
+}
+
+// String implements the fmt.Stringer interface.
+func (p *PuzzleAndSolutionRequest) String() string {
+	return requestString(p)
+}
+
+// RecentSignagePointOrEOSResponse represents the Chia RPC API's response to a RecentSignagePointOrEOSRequest.
+type RecentSignagePointOrEOSResponse struct {
+	SignagePoint json.RawMessage `json:"signage_point,omitempty"` // Set when requested by SpHash.
+	EOS          json.RawMessage `json:"eos,omitempty"`           // Set when requested by ChallengeHash.
+	TimeReceived float64         `json:"time_received"`
+	Reverted     bool            `json:"reverted"`
+	Response
+}
+
+// RecentSignagePointOrEOSRequest is a type for making a request for a recent signage point, by hash, or end of sub-slot, by challenge hash. Set one of SpHash and ChallengeHash.
+type RecentSignagePointOrEOSRequest struct {
+	SpHash        string `json:"sp_hash,omitempty"`
+	ChallengeHash string `json:"challenge_hash,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (r *RecentSignagePointOrEOSRequest) Procedure() Procedure {
+	return FullNodeGetRecentSignagePointOrEOS
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (r *RecentSignagePointOrEOSRequest) Send(e *Endpoint) (*RecentSignagePointOrEOSResponse, error) {
+	return r.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (r *RecentSignagePointOrEOSRequest) SendContext(ctx context.Context, caller Caller) (*RecentSignagePointOrEOSResponse, error) {
+	return Do[*RecentSignagePointOrEOSRequest, RecentSignagePointOrEOSResponse](ctx, caller, r)
+}
+
+// String implements the fmt.Stringer interface.
+func (r *RecentSignagePointOrEOSRequest) String() string {
+	return requestString(r)
+}
+
+// AllMempoolTxIdsResponse represents the Chia RPC API's response to a AllMempoolTxIdsRequest.
+type AllMempoolTxIdsResponse struct {
+	TxIds []string `json:"tx_ids"`
+	Response
+}
+
+// AllMempoolTxIdsRequest is a type for making a request for the IDs of all transactions in the mempool.
+type AllMempoolTxIdsRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (a *AllMempoolTxIdsRequest) Procedure() Procedure {
+	return FullNodeGetAllMempoolTxIds
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (a *AllMempoolTxIdsRequest) Send(e *Endpoint) (*AllMempoolTxIdsResponse, error) {
+	return a.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (a *AllMempoolTxIdsRequest) SendContext(ctx context.Context, caller Caller) (*AllMempoolTxIdsResponse, error) {
+	return Do[*AllMempoolTxIdsRequest, AllMempoolTxIdsResponse](ctx, caller, a)
+}
+
+// String implements the fmt.Stringer interface.
+func (a *AllMempoolTxIdsRequest) String() string {
+	return requestString(a)
+}
+
+// AllMempoolItemsResponse represents the Chia RPC API's response to a AllMempoolItemsRequest.
+type AllMempoolItemsResponse struct {
+	MempoolItems map[string]*MempoolItem `json:"mempool_items"`
+	Response
+}
+
+// AllMempoolItemsRequest is a type for making a request for all items in the mempool, keyed by transaction ID.
+type AllMempoolItemsRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (a *AllMempoolItemsRequest) Procedure() Procedure {
+	return FullNodeGetAllMempoolItems
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (a *AllMempoolItemsRequest) Send(e *Endpoint) (*AllMempoolItemsResponse, error) {
+	return a.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (a *AllMempoolItemsRequest) SendContext(ctx context.Context, caller Caller) (*AllMempoolItemsResponse, error) {
+	return Do[*AllMempoolItemsRequest, AllMempoolItemsResponse](ctx, caller, a)
+}
+
+// String implements the fmt.Stringer interface.
+func (a *AllMempoolItemsRequest) String() string {
+	return requestString(a)
+}
+
+// MempoolItemByTxIdResponse represents the Chia RPC API's response to a MempoolItemByTxIdRequest.
+type MempoolItemByTxIdResponse struct {
+	MempoolItem *MempoolItem `json:"mempool_item"`
+	Response
+}
+
+// MempoolItemByTxIdRequest is a type for making a request for a mempool item by transaction ID.
+type MempoolItemByTxIdRequest struct {
+	TxId           string `json:"tx_id"`
+	IncludePending bool   `json:"include_pending,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (m *MempoolItemByTxIdRequest) Procedure() Procedure {
+	return FullNodeGetMempoolItemByTxId
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (m *MempoolItemByTxIdRequest) Send(e *Endpoint) (*MempoolItemByTxIdResponse, error) {
+	return m.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (m *MempoolItemByTxIdRequest) SendContext(ctx context.Context, caller Caller) (*MempoolItemByTxIdResponse, error) {
+	return Do[*MempoolItemByTxIdRequest, MempoolItemByTxIdResponse](ctx, caller, m)
+}
+
+// String implements the fmt.Stringer interface.
+func (m *MempoolItemByTxIdRequest) String() string {
+	return requestString(m)
+}
+
+// RoutesResponse represents the Chia RPC API's response to a RoutesRequest.
+type RoutesResponse struct {
+	Routes []string `json:"routes"`
+	Response
+}
+
+// RoutesRequest is a type for making a request for the routes, or procedures, a service serves.
+type RoutesRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (r *RoutesRequest) Procedure() Procedure {
+	return GetRoutes
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (r *RoutesRequest) Send(e *Endpoint) (*RoutesResponse, error) {
+	return r.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (r *RoutesRequest) SendContext(ctx context.Context, caller Caller) (*RoutesResponse, error) {
+	return Do[*RoutesRequest, RoutesResponse](ctx, caller, r)
+}
+
+// String implements the fmt.Stringer interface.
+func (r *RoutesRequest) String() string {
+	return requestString(r)
+}
+
+// HealthzResponse represents the Chia RPC API's response to a HealthzRequest.
+type HealthzResponse struct {
+	Response
+}
+
+// HealthzRequest is a type for making a request for whether a service is up.
+type HealthzRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (h *HealthzRequest) Procedure() Procedure {
+	return Healthz
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (h *HealthzRequest) Send(e *Endpoint) (*HealthzResponse, error) {
+	return h.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (h *HealthzRequest) SendContext(ctx context.Context, caller Caller) (*HealthzResponse, error) {
+	return Do[*HealthzRequest, HealthzResponse](ctx, caller, h)
+}
+
+// String implements the fmt.Stringer interface.
+func (h *HealthzRequest) String() string {
+	return requestString(h)
+}
@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAmountBytes(t *testing.T) {
+	for a, want := range map[uint64][]byte{
+		0:         {},
+		1:         {0x01},
+		127:       {0x7f},
+		128:       {0x00, 0x80},
+		255:       {0x00, 0xff},
+		256:       {0x01, 0x00},
+		1<<64 - 1: {0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	} {
+		if got := amountBytes(a); !bytes.Equal(got, want) {
+			t.Errorf("amountBytes(%d) = %x, want %x", a, got, want)
+		}
+	}
+}
+
+func TestCoinRecordsRequest(t *testing.T) {
+	coin := func(amount int) string {
+		return `{"coin": {"amount": ` + strconv.Itoa(amount) + `, "parent_coin_info": "0x` + strings.Repeat("11", 32) + `", "puzzle_hash": "0x` + strings.Repeat("22", 32) + `"}}`
+	}
+	var mu sync.Mutex
+	called := make(map[Procedure]string)
+	c := CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+		mu.Lock()
+		called[p] = string(j)
+		mu.Unlock()
+		switch p {
+		case FullNodeCoinRecordByNames:
+			return []byte(`{"success": true, "coin_records": [` + coin(1) + `, ` + coin(2) + `]}`), nil
+		case FullNodeCoinRecordByParentIds:
+			// The same coin as found by name.
+			return []byte(`{"success": true, "coin_records": [` + coin(2) + `, ` + coin(3) + `]}`), nil
+		}
+		return []byte(`{"success": false, "error": "boom"}`), nil
+	})
+	r, err := (&CoinRecordsRequest{Names: []string{"0x01"}, ParentIds: []string{"0x02"}}).SendContext(context.Background(), c)
+	if err != nil {
+		t.Fatalf("Request failed: %s", err)
+	}
+	if len(called) != 2 || called[FullNodeCoinRecordByParentIds] != `{"parent_ids":["0x02"]}` {
+		t.Errorf("Unexpected calls %v", called)
+	}
+	if len(r.CoinRecords) != 3 {
+		t.Errorf("Expected 3 distinct records, got %d", len(r.CoinRecords))
+	}
+
+	// A failing hints query is reported, along with the records of the others.
+	r, err = (&CoinRecordsRequest{Names: []string{"0x01"}, Hints: []string{"0x03"}}).SendContext(context.Background(), c)
+	var cerr *CoinRecordsError
+	if !errors.As(err, &cerr) || len(cerr.Errors) != 1 || cerr.Errors[FullNodeCoinRecordByHints] == nil {
+		t.Fatalf("Expected a *CoinRecordsError for hints, got %v", err)
+	}
+	if _, ok := AsAPIError(err); !ok {
+		t.Error("Expected the *APIError to be unwrappable")
+	}
+	if r == nil || len(r.CoinRecords) != 2 {
+		t.Errorf("Expected the records by name, got %+v", r)
+	}
+
+	if _, err := new(CoinRecordsRequest).SendContext(context.Background(), c); err == nil {
+		t.Error("Expected an error for an empty request")
+	}
+}
@@ -0,0 +1,71 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+	"github.com/Jsewill/chia/rpc/rpctest"
+)
+
+func TestBlockchainState(t *testing.T) {
+	n := rpctest.NewServer(t, rpc.ServiceFullNode).Endpoint()
+	r, err := new(rpc.BlockchainStateRequest).SendContext(context.Background(), n)
+	if err != nil {
+		t.Fatalf("Blockchain State Request failed: %s", err)
+	}
+	s := r.BlockchainState
+	if !s.Sync.Synced || s.Peak.Height != 1000 || !s.Peak.IsTransactionBlock() {
+		t.Errorf("Unexpected state: %+v", s)
+	}
+	// Network space overflows 64 bits.
+	if s.Space.String() != "32000000000000000000" {
+		t.Errorf("Unexpected space %s", s.Space)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceFullNode)
+	n := s.Endpoint()
+	ctx := context.Background()
+	r, err := (&rpc.BlocksRequest{Start: 1000, End: 1001}).SendContext(ctx, n)
+	if err != nil {
+		t.Fatalf("Blocks Request failed: %s", err)
+	}
+	if len(r.Blocks) != 1 || r.Blocks[0].RewardChainBlock.Height != 1000 || r.Blocks[0].TransactionsInfo == nil {
+		t.Errorf("Unexpected blocks: %+v", r.Blocks)
+	}
+	if c := s.CallsTo(rpc.FullNodeGetBlocks); len(c) != 1 || string(c[0].Request) != `{"start":1000,"end":1001}` {
+		t.Errorf("Unexpected calls: %v", c)
+	}
+	br, err := (&rpc.BlockRecordByHeightRequest{Height: 1000}).SendContext(ctx, n)
+	if err != nil || br.BlockRecord.HeaderHash != rpctest.FixtureHeaderHash {
+		t.Errorf("Unexpected block record: %+v, %v", br, err)
+	}
+}
+
+func TestMempool(t *testing.T) {
+	n := rpctest.NewServer(t, rpc.ServiceFullNode).Endpoint()
+	ctx := context.Background()
+	r, err := new(rpc.AllMempoolItemsRequest).SendContext(ctx, n)
+	if err != nil {
+		t.Fatalf("All Mempool Items Request failed: %s", err)
+	}
+	m, ok := r.MempoolItems[rpctest.FixtureTxId]
+	if !ok || m.FeeRate() != 0.01 || len(m.SpendBundle.CoinSolutions) != 1 {
+		t.Errorf("Unexpected mempool items: %+v", r.MempoolItems)
+	}
+	// Older versions of Chia call coin spends coin solutions.
+	var sb rpc.SpendBundle
+	if err := json.Unmarshal([]byte(`{"coin_solutions": [{"puzzle_reveal": "0x01", "solution": "0x80"}]}`), &sb); err != nil || len(sb.CoinSolutions) != 1 {
+		t.Errorf("Unexpected spend bundle: %+v, %v", sb, err)
+	}
+	routes, err := new(rpc.RoutesRequest).SendContext(ctx, n)
+	if err != nil || len(routes.Routes) == 0 {
+		t.Errorf("Unexpected routes: %+v, %v", routes, err)
+	}
+	if _, err := new(rpc.HealthzRequest).SendContext(ctx, n); err != nil {
+		t.Errorf("Healthz Request failed: %s", err)
+	}
+}
@@ -1,12 +1,6 @@
 package rpc
 
 var (
-	Harvester *Endpoint = &Endpoint{Name: "harvester", Host: defaultHost, Port: 8560}
+	// Harvester is a convenience harvester endpoint, configured and initialized on first use. See Endpoint.
+	Harvester *Endpoint = &Endpoint{Name: ServiceHarvester}
 )
-
-func init() {
-	err := Harvester.Init()
-	if err != nil {
-		logErr.Panicln(err)
-	}
-}
@@ -0,0 +1,272 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrPrivateKeyNotAllowed is returned by a PrivateKeyRequest which hasn't opted in to fetching a private key.
+var ErrPrivateKeyNotAllowed = errors.New("private key request not allowed")
+
+// LogInResponse represents the Chia RPC API's response to a LogInRequest.
+type LogInResponse struct {
+	Fingerprint uint `json:"fingerprint"`
+	Response
+}
+
+// LogInRequest is a type for making a request to switch the wallet service to the key with the given fingerprint.
+type LogInRequest struct {
+	Fingerprint uint `json:"fingerprint"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (l *LogInRequest) Procedure() Procedure {
+	return WalletLogIn
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (l *LogInRequest) Send(e *Endpoint) (*LogInResponse, error) {
+	return l.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (l *LogInRequest) SendContext(ctx context.Context, caller Caller) (*LogInResponse, error) {
+	return Do[*LogInRequest, LogInResponse](ctx, caller, l)
+}
+
+// String implements the fmt.Stringer interface.
+func (l *LogInRequest) String() string {
+	return requestString(l)
+}
+
+// LoggedInFingerprintResponse represents the Chia RPC API's response to a LoggedInFingerprintRequest.
+type LoggedInFingerprintResponse struct {
+	Fingerprint *uint `json:"fingerprint"` // Nil if no key is logged in.
+	Response
+}
+
+// LoggedInFingerprintRequest is a type for making a request for the fingerprint of the key the wallet service is logged in with.
+type LoggedInFingerprintRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (l *LoggedInFingerprintRequest) Procedure() Procedure {
+	return WalletGetLoggedInFingerprint
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (l *LoggedInFingerprintRequest) Send(e *Endpoint) (*LoggedInFingerprintResponse, error) {
+	return l.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (l *LoggedInFingerprintRequest) SendContext(ctx context.Context, caller Caller) (*LoggedInFingerprintResponse, error) {
+	return Do[*LoggedInFingerprintRequest, LoggedInFingerprintResponse](ctx, caller, l)
+}
+
+// String implements the fmt.Stringer interface.
+func (l *LoggedInFingerprintRequest) String() string {
+	return requestString(l)
+}
+
+// PublicKeysResponse represents the Chia RPC API's response to a PublicKeysRequest.
+type PublicKeysResponse struct {
+	PublicKeyFingerprints []uint `json:"public_key_fingerprints"`
+	KeyringIsLocked       bool   `json:"keyring_is_locked,omitempty"` // If true, the fingerprints couldn't be read.
+	Response
+}
+
+// PublicKeysRequest is a type for making a request for the fingerprints of the keys in the keychain.
+type PublicKeysRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (p *PublicKeysRequest) Procedure() Procedure {
+	return WalletGetPublicKeys
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (p *PublicKeysRequest) Send(e *Endpoint) (*PublicKeysResponse, error) {
+	return p.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (p *PublicKeysRequest) SendContext(ctx context.Context, caller Caller) (*PublicKeysResponse, error) {
+	return Do[*PublicKeysRequest, PublicKeysResponse](ctx, caller, p)
+}
+
+// String implements the fmt.Stringer interface.
+func (p *PublicKeysRequest) String() string {
+	return requestString(p)
+}
+
+// PrivateKey holds a key's secrets, as returned by a PrivateKeyRequest. Handle with care; see RedactedKeys.
+type PrivateKey struct {
+	Fingerprint uint   `json:"fingerprint"`
+	Sk          string `json:"sk"`
+	Pk          string `json:"pk"`
+	FarmerPk    string `json:"farmer_pk"`
+	PoolPk      string `json:"pool_pk"`
+	Seed        string `json:"seed"` // The mnemonic, if the key was added with one.
+}
+
+// String implements the fmt.Stringer interface. The key's secrets are redacted, so that printing it doesn't leak them.
+func (p *PrivateKey) String() string {
+	j, _ := json.Marshal(p)
+	return string(Redact(j))
+}
+
+// GoString implements the fmt.GoStringer interface, redacting as String does.
+func (p *PrivateKey) GoString() string {
+	return p.String()
+}
+
+// PrivateKeyResponse represents the Chia RPC API's response to a PrivateKeyRequest.
+type PrivateKeyResponse struct {
+	PrivateKey *PrivateKey `json:"private_key"`
+	Response
+}
+
+// PrivateKeyRequest is a type for making a request for the secrets of the key with the given fingerprint. Since the response holds the key's mnemonic, the request fails with ErrPrivateKeyNotAllowed, without being sent, unless AllowPrivateKey is set.
+type PrivateKeyRequest struct {
+	Fingerprint     uint `json:"fingerprint"`
+	AllowPrivateKey bool `json:"-"` // Opts in to fetching the private key.
+}
+
+// Procedure returns the Procedure which this request will use.
+func (p *PrivateKeyRequest) Procedure() Procedure {
+	return WalletGetPrivateKey
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (p *PrivateKeyRequest) Send(e *Endpoint) (*PrivateKeyResponse, error) {
+	return p.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (p *PrivateKeyRequest) SendContext(ctx context.Context, caller Caller) (*PrivateKeyResponse, error) {
+	if !p.AllowPrivateKey {
+		return nil, fmt.Errorf("Private key of %d requested without AllowPrivateKey: %w", p.Fingerprint, ErrPrivateKeyNotAllowed)
+	}
+	return Do[*PrivateKeyRequest, PrivateKeyResponse](ctx, caller, p)
+}
+
+// String implements the fmt.Stringer interface.
+func (p *PrivateKeyRequest) String() string {
+	return requestString(p)
+}
+
+// GenerateMnemonicResponse represents the Chia RPC API's response to a GenerateMnemonicRequest.
+type GenerateMnemonicResponse struct {
+	Mnemonic []string `json:"mnemonic"`
+	Response
+}
+
+// GenerateMnemonicRequest is a type for making a request for a new 24 word mnemonic. The key isn't added to the keychain; see AddKeyRequest.
+type GenerateMnemonicRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (g *GenerateMnemonicRequest) Procedure() Procedure {
+	return WalletGenerateMnemonic
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (g *GenerateMnemonicRequest) Send(e *Endpoint) (*GenerateMnemonicResponse, error) {
+	return g.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (g *GenerateMnemonicRequest) SendContext(ctx context.Context, caller Caller) (*GenerateMnemonicResponse, error) {
+	return Do[*GenerateMnemonicRequest, GenerateMnemonicResponse](ctx, caller, g)
+}
+
+// String implements the fmt.Stringer interface.
+func (g *GenerateMnemonicRequest) String() string {
+	return requestString(g)
+}
+
+// AddKeyResponse represents the Chia RPC API's response to an AddKeyRequest.
+type AddKeyResponse struct {
+	Fingerprint uint `json:"fingerprint"`
+	Response
+}
+
+// AddKeyRequest is a type for making a request to add the key of a mnemonic to the keychain, and log in with it.
+type AddKeyRequest struct {
+	Mnemonic []string `json:"mnemonic"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (a *AddKeyRequest) Procedure() Procedure {
+	return WalletAddKey
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (a *AddKeyRequest) Send(e *Endpoint) (*AddKeyResponse, error) {
+	return a.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (a *AddKeyRequest) SendContext(ctx context.Context, caller Caller) (*AddKeyResponse, error) {
+	return Do[*AddKeyRequest, AddKeyResponse](ctx, caller, a)
+}
+
+// String implements the fmt.Stringer interface.
+func (a *AddKeyRequest) String() string {
+	return requestString(a)
+}
+
+// DeleteKeyResponse represents the Chia RPC API's response to a DeleteKeyRequest.
+type DeleteKeyResponse struct {
+	Response
+}
+
+// DeleteKeyRequest is a type for making a request to remove the key with the given fingerprint from the keychain.
+type DeleteKeyRequest struct {
+	Fingerprint uint `json:"fingerprint"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (d *DeleteKeyRequest) Procedure() Procedure {
+	return WalletDeleteKey
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (d *DeleteKeyRequest) Send(e *Endpoint) (*DeleteKeyResponse, error) {
+	return d.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (d *DeleteKeyRequest) SendContext(ctx context.Context, caller Caller) (*DeleteKeyResponse, error) {
+	return Do[*DeleteKeyRequest, DeleteKeyResponse](ctx, caller, d)
+}
+
+// String implements the fmt.Stringer interface.
+func (d *DeleteKeyRequest) String() string {
+	return requestString(d)
+}
+
+// WithFingerprint logs the wallet service behind c in to the key with fingerprint fp, calls f, and then logs back in to the key which was logged in before, even if f fails. If fp is already logged in, f is just called.
+// The wallet service has a single logged in key, so callers sharing it must not switch keys concurrently.
+func WithFingerprint(ctx context.Context, c Caller, fp uint, f func(ctx context.Context) error) (err error) {
+	r, err := new(LoggedInFingerprintRequest).SendContext(ctx, c)
+	if err != nil {
+		return fmt.Errorf("Couldn't get logged in fingerprint. Error: %w", err)
+	}
+	if r.Fingerprint != nil && *r.Fingerprint == fp {
+		return f(ctx)
+	}
+	if _, err := (&LogInRequest{Fingerprint: fp}).SendContext(ctx, c); err != nil {
+		return fmt.Errorf("Couldn't log in to key %d. Error: %w", fp, err)
+	}
+	if r.Fingerprint != nil {
+		prev := *r.Fingerprint
+		defer func() {
+			// Restore the key even if ctx is done.
+			if _, rerr := (&LogInRequest{Fingerprint: prev}).SendContext(context.WithoutCancel(ctx), c); rerr != nil {
+				err = errors.Join(err, fmt.Errorf("Couldn't log back in to key %d. Error: %w", prev, rerr))
+			}
+		}()
+	}
+	return f(ctx)
+}
@@ -0,0 +1,89 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+	"github.com/Jsewill/chia/rpc/rpctest"
+)
+
+// keychain serves log_in and get_logged_in_fingerprint from a logged in fingerprint.
+func keychain(s *rpctest.Server, fp uint) {
+	s.Handle(rpc.WalletLogIn, func(req []byte) (any, error) {
+		var r rpc.LogInRequest
+		if err := json.Unmarshal(req, &r); err != nil {
+			return nil, err
+		}
+		fp = r.Fingerprint
+		return map[string]any{"fingerprint": fp}, nil
+	})
+	s.Handle(rpc.WalletGetLoggedInFingerprint, func([]byte) (any, error) {
+		return map[string]any{"fingerprint": fp}, nil
+	})
+}
+
+func TestWithFingerprint(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	keychain(s, 1)
+	w := s.Endpoint()
+	ctx := context.Background()
+	var during uint
+	errF := errors.New("payout failed")
+	err := rpc.WithFingerprint(ctx, w, 2, func(ctx context.Context) error {
+		r, err := new(rpc.LoggedInFingerprintRequest).SendContext(ctx, w)
+		if err != nil {
+			return err
+		}
+		during = *r.Fingerprint
+		return errF
+	})
+	if !errors.Is(err, errF) || during != 2 {
+		t.Errorf("Expected f to fail logged in to 2, got %v, logged in to %d", err, during)
+	}
+	if r, err := new(rpc.LoggedInFingerprintRequest).SendContext(ctx, w); err != nil || *r.Fingerprint != 1 {
+		t.Errorf("Expected key 1 restored, got %+v, %v", r, err)
+	}
+	if cs := s.CallsTo(rpc.WalletLogIn); len(cs) != 2 || string(cs[1].Request) != `{"fingerprint":1}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	// Already logged in; no switching.
+	if err := rpc.WithFingerprint(ctx, w, 1, func(context.Context) error { return nil }); err != nil || len(s.CallsTo(rpc.WalletLogIn)) != 2 {
+		t.Errorf("Unexpected log in: %v, %v", err, s.CallsTo(rpc.WalletLogIn))
+	}
+}
+
+func TestPrivateKeyOptIn(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	w := s.Endpoint()
+	if _, err := (&rpc.PrivateKeyRequest{Fingerprint: 1234567890}).SendContext(context.Background(), w); !errors.Is(err, rpc.ErrPrivateKeyNotAllowed) {
+		t.Errorf("Expected ErrPrivateKeyNotAllowed, got %v", err)
+	}
+	if cs := s.CallsTo(rpc.WalletGetPrivateKey); len(cs) != 0 {
+		t.Errorf("Request sent without opting in: %v", cs)
+	}
+	r, err := (&rpc.PrivateKeyRequest{Fingerprint: 1234567890, AllowPrivateKey: true}).SendContext(context.Background(), w)
+	if err != nil || r.PrivateKey.Fingerprint != 1234567890 {
+		t.Errorf("Unexpected private key: %+v, %v", r, err)
+	}
+	if cs := s.CallsTo(rpc.WalletGetPrivateKey); len(cs) != 1 || string(cs[0].Request) != `{"fingerprint":1234567890}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	// Printing the key doesn't leak its secrets.
+	for _, f := range []string{"%v", "%+v", "%#v", "%s"} {
+		if p := fmt.Sprintf(f, r.PrivateKey); r.PrivateKey.Sk == "" || strings.Contains(p, r.PrivateKey.Sk) || strings.Contains(p, r.PrivateKey.Seed) {
+			t.Errorf("Printed with %s: %s", f, p)
+		}
+	}
+}
+
+func TestAddKeyRequestString(t *testing.T) {
+	r := &rpc.AddKeyRequest{Mnemonic: []string{"abandon", "ability", "able"}}
+	if s := r.String(); strings.Contains(s, "abandon") || !strings.Contains(s, "REDACTED") {
+		t.Errorf("String leaks the mnemonic: %s", s)
+	}
+}
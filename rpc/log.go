@@ -1,23 +1,97 @@
 package rpc
 
 import (
-	"log"
-	"os"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync/atomic"
 )
 
-const (
-	logPrefix = "chia/rpc: "
-)
+// discardHandler is a slog.Handler which discards every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
 
 var (
-	logErr *log.Logger
+	discardLogger = slog.New(discardHandler{})
+	defaultLogger atomic.Pointer[slog.Logger]
 )
 
-func init() {
-	var (
-		logErr = log.Default()
-		logger = log.New(os.Stdout, logPrefix, log.LstdFlags)
-	)
-	_ = logger
-	logErr.SetPrefix(logPrefix)
+// SetLogger sets the package's default *slog.Logger, used by Endpoints with no Logger of their own. The package is silent until this is called; passing nil silences it again.
+func SetLogger(l *slog.Logger) {
+	defaultLogger.Store(l)
+}
+
+// logger returns l, or the package's default *slog.Logger if l is nil.
+func logger(l *slog.Logger) *slog.Logger {
+	if l != nil {
+		return l
+	}
+	if l = defaultLogger.Load(); l != nil {
+		return l
+	}
+	return discardLogger
+}
+
+// RedactedKeys holds the JSON object keys, in lower case, whose values are replaced by Redact. Any key containing one of these, such as "farmer_sk", is redacted.
+var RedactedKeys = []string{"mnemonic", "seed", "private_key", "secret", "_sk", "password", "passphrase"}
+
+const redacted = "[REDACTED]"
+
+// Redact returns a copy of the JSON document j with the values of any object keys matching RedactedKeys replaced, at any depth. If j isn't JSON, or has nothing to redact, it is returned as is.
+func Redact(j []byte) []byte {
+	var v any
+	if err := json.Unmarshal(j, &v); err != nil {
+		return j
+	}
+	if !redact(v) {
+		return j
+	}
+	r, err := json.Marshal(v)
+	if err != nil {
+		return j
+	}
+	return r
+}
+
+// redact replaces the values of redacted keys in v, and reports whether there were any.
+func redact(v any) bool {
+	found := false
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if isRedactedKey(k) {
+				t[k] = redacted
+				found = true
+				continue
+			}
+			if redact(e) {
+				found = true
+			}
+		}
+	case []any:
+		for _, e := range t {
+			if redact(e) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+func isRedactedKey(k string) bool {
+	k = strings.ToLower(k)
+	if k == "sk" {
+		return true
+	}
+	for _, r := range RedactedKeys {
+		if strings.Contains(k, r) {
+			return true
+		}
+	}
+	return false
 }
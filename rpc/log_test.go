@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	in := []byte(`{"fingerprint": 1, "private_key": {"sk": "aa", "farmer_sk": "bb", "pk": "cc"}, "keys": [{"mnemonic": ["a", "b"]}]}`)
+	var out map[string]any
+	if err := json.Unmarshal(Redact(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["fingerprint"].(float64) != 1 {
+		t.Errorf("Fingerprint was redacted: %v", out)
+	}
+	if out["private_key"] != redacted {
+		t.Errorf("Private key wasn't redacted: %v", out)
+	}
+	if k := out["keys"].([]any)[0].(map[string]any); k["mnemonic"] != redacted {
+		t.Errorf("Mnemonic wasn't redacted: %v", out)
+	}
+	if s := string(Redact([]byte("not json"))); s != "not json" {
+		t.Errorf("Non-JSON was changed: %s", s)
+	}
+}
+
+func TestEndpointLogger(t *testing.T) {
+	var buf bytes.Buffer
+	e := newTestServer(t, newTestCA(t), nil)
+	e.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if _, err := e.Call(WalletSyncStatus, []byte(`{"mnemonic": "abandon abandon"}`)); err != nil {
+		t.Fatal(err)
+	}
+	l := buf.String()
+	for _, want := range []string{"procedure=get_sync_status", "endpoint=" + e.String(), "latency=", "status=200", redacted} {
+		if !strings.Contains(l, want) {
+			t.Errorf("Log is missing %q: %s", want, l)
+		}
+	}
+	if strings.Contains(l, "abandon") {
+		t.Errorf("Log contains a secret: %s", l)
+	}
+}
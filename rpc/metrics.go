@@ -0,0 +1,197 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the latency histogram buckets used by NewMetrics.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// metricsKey identifies the calls to one procedure of one endpoint.
+type metricsKey struct {
+	endpoint  string
+	procedure Procedure
+}
+
+// procedureMetrics holds the metrics of the calls to one procedure of one endpoint.
+type procedureMetrics struct {
+	requests        uint64
+	transportErrors uint64
+	apiErrors       uint64
+	inFlight        int64
+	buckets         []uint64 // Cumulative counts, one per bucket bound.
+	sum             float64
+	count           uint64
+}
+
+// Metrics collects per-procedure RPC call counts, error counts, latency histograms and in-flight gauges, and exports them in the Prometheus text exposition format.
+// Set it as an Endpoint's Metrics, or wrap any Caller with its Middleware. It is safe for concurrent use.
+type Metrics struct {
+	buckets []float64
+	mu      sync.Mutex
+	procs   map[metricsKey]*procedureMetrics
+}
+
+// NewMetrics returns a new *Metrics, with latency histogram buckets bounded by b, in seconds. If b is empty, DefaultBuckets is used.
+func NewMetrics(b ...float64) *Metrics {
+	if len(b) == 0 {
+		b = DefaultBuckets
+	}
+	b = append([]float64(nil), b...)
+	sort.Float64s(b)
+	return &Metrics{buckets: b, procs: make(map[metricsKey]*procedureMetrics)}
+}
+
+// get returns the metrics for the key, creating them if necessary. The caller must hold m.mu.
+func (m *Metrics) get(k metricsKey) *procedureMetrics {
+	pm, ok := m.procs[k]
+	if !ok {
+		pm = &procedureMetrics{buckets: make([]uint64, len(m.buckets))}
+		m.procs[k] = pm
+	}
+	return pm
+}
+
+// Begin records the start of a call to procedure p of endpoint e, and returns a function which records its end, given its error.
+func (m *Metrics) Begin(e string, p Procedure) func(error) {
+	k := metricsKey{e, p}
+	start := time.Now()
+	m.mu.Lock()
+	m.get(k).inFlight++
+	m.mu.Unlock()
+	return func(err error) {
+		d := time.Since(start).Seconds()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		pm := m.get(k)
+		pm.inFlight--
+		pm.requests++
+		if err != nil {
+			if _, ok := AsAPIError(err); ok {
+				pm.apiErrors++
+			} else {
+				pm.transportErrors++
+			}
+		}
+		for i, b := range m.buckets {
+			if d <= b {
+				pm.buckets[i]++
+			}
+		}
+		pm.sum += d
+		pm.count++
+	}
+}
+
+// Middleware returns Middleware which records the metrics of each call. Calls are labeled with the wrapped Caller's description, such as an Endpoint's URI, if it has one.
+func (m *Metrics) Middleware() Middleware {
+	return func(next Caller) Caller {
+		var e string
+		if s, ok := next.(fmt.Stringer); ok {
+			e = s.String()
+		}
+		return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+			end := m.Begin(e, p)
+			b, err := next.CallContext(ctx, p, j)
+			end(err)
+			return b, err
+		})
+	}
+}
+
+// Handler returns an http.Handler which serves the metrics in the Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.WriteTo(w)
+	})
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format. Implements io.WriterTo.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	keys := make([]metricsKey, 0, len(m.procs))
+	snap := make(map[metricsKey]procedureMetrics, len(m.procs))
+	for k, pm := range m.procs {
+		keys = append(keys, k)
+		s := *pm
+		s.buckets = append([]uint64(nil), pm.buckets...)
+		snap[k] = s
+	}
+	m.mu.Unlock()
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].procedure < keys[j].procedure
+	})
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	labels := func(k metricsKey, extra ...string) string {
+		l := []string{`endpoint="` + escapeLabel(k.endpoint) + `"`, `procedure="` + escapeLabel(string(k.procedure)) + `"`}
+		return "{" + strings.Join(append(l, extra...), ",") + "}"
+	}
+
+	fmt.Fprintln(cw, "# HELP chia_rpc_requests_total Total number of completed Chia RPC calls.")
+	fmt.Fprintln(cw, "# TYPE chia_rpc_requests_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(cw, "chia_rpc_requests_total%s %d\n", labels(k), snap[k].requests)
+	}
+	fmt.Fprintln(cw, "# HELP chia_rpc_errors_total Total number of failed Chia RPC calls, by kind: transport failures, or API errors such as \"success\": false.")
+	fmt.Fprintln(cw, "# TYPE chia_rpc_errors_total counter")
+	for _, k := range keys {
+		fmt.Fprintf(cw, "chia_rpc_errors_total%s %d\n", labels(k, `kind="transport"`), snap[k].transportErrors)
+		fmt.Fprintf(cw, "chia_rpc_errors_total%s %d\n", labels(k, `kind="api"`), snap[k].apiErrors)
+	}
+	fmt.Fprintln(cw, "# HELP chia_rpc_in_flight_requests Number of Chia RPC calls in progress.")
+	fmt.Fprintln(cw, "# TYPE chia_rpc_in_flight_requests gauge")
+	for _, k := range keys {
+		fmt.Fprintf(cw, "chia_rpc_in_flight_requests%s %d\n", labels(k), snap[k].inFlight)
+	}
+	fmt.Fprintln(cw, "# HELP chia_rpc_request_duration_seconds Latency of Chia RPC calls.")
+	fmt.Fprintln(cw, "# TYPE chia_rpc_request_duration_seconds histogram")
+	for _, k := range keys {
+		s := snap[k]
+		for i, b := range m.buckets {
+			fmt.Fprintf(cw, "chia_rpc_request_duration_seconds_bucket%s %d\n", labels(k, `le="`+strconv.FormatFloat(b, 'g', -1, 64)+`"`), s.buckets[i])
+		}
+		fmt.Fprintf(cw, "chia_rpc_request_duration_seconds_bucket%s %d\n", labels(k, `le="+Inf"`), s.count)
+		fmt.Fprintf(cw, "chia_rpc_request_duration_seconds_sum%s %s\n", labels(k), strconv.FormatFloat(s.sum, 'g', -1, 64))
+		fmt.Fprintf(cw, "chia_rpc_request_duration_seconds_count%s %d\n", labels(k), s.count)
+	}
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// escapeLabel escapes a Prometheus label value.
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// countingWriter counts the bytes written through it, and remembers the first error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics(0.5, 1)
+	e := newTestServer(t, newTestCA(t), func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, string(WalletGetBalance)) {
+			w.Write([]byte(`{"success": false, "error": "Wallet id 9 does not exist"}`))
+			return
+		}
+		w.Write([]byte(`{"success": true}`))
+	})
+	e.Metrics = m
+	e.Call(WalletSyncStatus, []byte(`{}`))
+	e.Call(WalletSyncStatus, []byte(`{}`))
+	e.Call(WalletGetBalance, []byte(`{}`))
+
+	// Middleware on a Caller which fails to reach the service.
+	down := CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+		return nil, errors.New("connection refused")
+	})
+	Chain(down, m.Middleware()).CallContext(context.Background(), FullNodePushTx, nil)
+
+	s := httptest.NewServer(m.Handler())
+	defer s.Close()
+	r, err := http.Get(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Body.Close()
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(b)
+	l := `endpoint="` + e.String() + `",`
+	for _, want := range []string{
+		"# TYPE chia_rpc_requests_total counter",
+		"chia_rpc_requests_total{" + l + `procedure="get_sync_status"} 2`,
+		"chia_rpc_errors_total{" + l + `procedure="get_wallet_balance",kind="api"} 1`,
+		"chia_rpc_errors_total{" + l + `procedure="get_wallet_balance",kind="transport"} 0`,
+		`chia_rpc_errors_total{endpoint="",procedure="push_tx",kind="transport"} 1`,
+		"chia_rpc_in_flight_requests{" + l + `procedure="get_sync_status"} 0`,
+		"chia_rpc_request_duration_seconds_bucket{" + l + `procedure="get_sync_status",le="+Inf"} 2`,
+		"chia_rpc_request_duration_seconds_count{" + l + `procedure="get_sync_status"} 2`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Metrics are missing %s\n%s", want, out)
+		}
+	}
+}
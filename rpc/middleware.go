@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// CallerFunc is an adapter which allows an ordinary function to be used as a Caller.
+type CallerFunc func(context.Context, Procedure, []byte) ([]byte, error)
+
+// CallContext calls f(ctx, p, j). Implements Caller.
+func (f CallerFunc) CallContext(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+	return f(ctx, p, j)
+}
+
+// Middleware wraps a Caller with cross-cutting behavior, returning a new Caller.
+type Middleware func(Caller) Caller
+
+// describedCaller is a Caller wrapped by Middleware, which keeps the description of the Caller it wraps, such as an Endpoint's URI.
+type describedCaller struct {
+	Caller
+	fmt.Stringer
+}
+
+// Chain returns c wrapped by each of mw. The first Middleware is the outermost, and so sees each call first.
+// If c implements fmt.Stringer, as Endpoint does, so does the returned Caller.
+func Chain(c Caller, mw ...Middleware) Caller {
+	for i := len(mw) - 1; i >= 0; i-- {
+		next := mw[i](c)
+		if s, ok := c.(fmt.Stringer); ok {
+			if _, ok := next.(fmt.Stringer); !ok {
+				next = describedCaller{next, s}
+			}
+		}
+		c = next
+	}
+	return c
+}
+
+// Logging returns Middleware which logs each call to l, with its procedure, latency, and error if it failed. Failures are logged at warning level, and successes at debug level.
+func Logging(l *slog.Logger) Middleware {
+	return func(next Caller) Caller {
+		return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+			start := time.Now()
+			b, err := next.CallContext(ctx, p, j)
+			attrs := []slog.Attr{slog.String("procedure", string(p)), slog.Duration("latency", time.Since(start))}
+			if err != nil {
+				l.LogAttrs(ctx, slog.LevelWarn, "rpc call failed", append(attrs, slog.Any("error", err))...)
+			} else {
+				l.LogAttrs(ctx, slog.LevelDebug, "rpc call", attrs...)
+			}
+			return b, err
+		})
+	}
+}
+
+// Timing returns Middleware which calls f with the procedure, duration and error of each call, once it returns.
+func Timing(f func(Procedure, time.Duration, error)) Middleware {
+	return func(next Caller) Caller {
+		return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+			start := time.Now()
+			b, err := next.CallContext(ctx, p, j)
+			f(p, time.Since(start), err)
+			return b, err
+		})
+	}
+}
+
+// Retrying returns Middleware which retries failed calls according to rp.
+func Retrying(rp RetryPolicy) Middleware {
+	return func(next Caller) Caller {
+		return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+			var b []byte
+			err := rp.Do(ctx, p, func() error {
+				var err error
+				b, err = next.CallContext(ctx, p, j)
+				return err
+			})
+			return b, err
+		})
+	}
+}
+
+// Rewrite returns Middleware which passes each call's procedure and request body through f before making it. If f returns an error, the call isn't made.
+func Rewrite(f func(Procedure, []byte) (Procedure, []byte, error)) Middleware {
+	return func(next Caller) Caller {
+		return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+			p, j, err := f(p, j)
+			if err != nil {
+				return nil, err
+			}
+			return next.CallContext(ctx, p, j)
+		})
+	}
+}
+
+// RateLimit returns Middleware which limits calls to r per second on average, allowing bursts of up to b calls. Calls over the limit wait their turn, or fail with ctx's error if it is done first. If r is not positive, calls are not limited.
+func RateLimit(r float64, b int) Middleware {
+	if b < 1 {
+		b = 1
+	}
+	tb := &tokenBucket{rate: r, burst: float64(b), tokens: float64(b), last: time.Now()}
+	return func(next Caller) Caller {
+		if r <= 0 {
+			return next
+		}
+		return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+			if err := tb.wait(ctx); err != nil {
+				return nil, err
+			}
+			return next.CallContext(ctx, p, j)
+		})
+	}
+}
+
+// tokenBucket implements the rate limiting of RateLimit.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes a token, waiting for one if necessary.
+func (tb *tokenBucket) wait(ctx context.Context) error {
+	for {
+		tb.mu.Lock()
+		now := time.Now()
+		tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+		if tb.tokens > tb.burst {
+			tb.tokens = tb.burst
+		}
+		tb.last = now
+		if tb.tokens >= 1 {
+			tb.tokens--
+			tb.mu.Unlock()
+			return nil
+		}
+		d := time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+		tb.mu.Unlock()
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// A RecordedCall is a call recorded by a Recorder.
+type RecordedCall struct {
+	Procedure Procedure
+	Request   []byte
+	Response  []byte
+	Err       error
+	Duration  time.Duration
+}
+
+// A Recorder records the calls made through its Middleware, in memory. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []RecordedCall
+}
+
+// Middleware returns Middleware which records each call to r.
+func (r *Recorder) Middleware() Middleware {
+	return func(next Caller) Caller {
+		return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+			start := time.Now()
+			b, err := next.CallContext(ctx, p, j)
+			r.mu.Lock()
+			r.calls = append(r.calls, RecordedCall{Procedure: p, Request: j, Response: b, Err: err, Duration: time.Since(start)})
+			r.mu.Unlock()
+			return b, err
+		})
+	}
+}
+
+// Calls returns the calls recorded so far, in the order they returned.
+func (r *Recorder) Calls() []RecordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedCall(nil), r.calls...)
+}
+
+// Reset discards the calls recorded so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
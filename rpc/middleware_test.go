@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next Caller) Caller {
+			return CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+				order = append(order, name)
+				return next.CallContext(ctx, p, j)
+			})
+		}
+	}
+	e := &Endpoint{Name: ServiceWallet, Host: "localhost", Port: 9256}
+	base := describedCaller{CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+		order = append(order, "caller")
+		return []byte(`{"success": true}`), nil
+	}), e}
+	c := Chain(base, mark("outer"), mark("inner"))
+	if _, err := c.CallContext(context.Background(), WalletSyncStatus, nil); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(order) != "[outer inner caller]" {
+		t.Errorf("Middleware ran in the order %v", order)
+	}
+	if s, ok := c.(fmt.Stringer); !ok || s.String() != e.String() {
+		t.Errorf("Chained Caller lost its description")
+	}
+}
+
+func TestRetryingAndRecorder(t *testing.T) {
+	attempts := 0
+	flaky := CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+		if attempts++; attempts < 3 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return []byte(`{"success": true, "synced": true}`), nil
+	})
+	rec := new(Recorder)
+	var timed []Procedure
+	c := Chain(flaky,
+		Timing(func(p Procedure, d time.Duration, err error) { timed = append(timed, p) }),
+		Retrying(testRetryPolicy),
+		rec.Middleware(),
+	)
+	r, err := (&SyncStatusRequest{}).SendContext(context.Background(), c)
+	if err != nil || !r.Synced {
+		t.Fatalf("Retried call failed: %v, %+v", err, r)
+	}
+	if calls := rec.Calls(); len(calls) != 3 || calls[0].Err == nil || calls[2].Err != nil {
+		t.Errorf("Recorded %d calls: %+v", len(calls), calls)
+	}
+	if len(timed) != 1 || timed[0] != WalletSyncStatus {
+		t.Errorf("Timed %v", timed)
+	}
+	rec.Reset()
+	if len(rec.Calls()) != 0 {
+		t.Error("Reset didn't discard the recorded calls")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ok := CallerFunc(func(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+		return []byte(`{"success": true}`), nil
+	})
+	c := Chain(ok, RateLimit(1, 2))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if _, err := c.CallContext(ctx, WalletSyncStatus, nil); err != nil {
+			t.Fatalf("Call %d within the burst failed: %s", i, err)
+		}
+	}
+	if _, err := c.CallContext(ctx, WalletSyncStatus, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Call over the limit didn't wait: %v", err)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	c := &cannedCaller{body: []byte(`{"success": true}`)}
+	rw := Chain(c, Rewrite(func(p Procedure, j []byte) (Procedure, []byte, error) {
+		if p == FullNodePushTx {
+			return p, nil, errors.New("read only")
+		}
+		return p, []byte(`{"wallet_id":1}`), nil
+	}))
+	if _, err := rw.CallContext(context.Background(), WalletGetBalance, []byte(`{}`)); err != nil || string(c.request) != `{"wallet_id":1}` {
+		t.Errorf("Request wasn't rewritten: %s, %v", c.request, err)
+	}
+	c.procedure = ""
+	if _, err := rw.CallContext(context.Background(), FullNodePushTx, []byte(`{}`)); err == nil || c.procedure != "" {
+		t.Error("Rejected call was made")
+	}
+}
@@ -0,0 +1,226 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GetNetworkInfo is served by both the full node and the wallet.
+const GetNetworkInfo Procedure = "get_network_info"
+
+// ErrWrongNetwork is returned when a request or service belongs to a different Chia network than expected.
+var ErrWrongNetwork = errors.New("wrong chia network")
+
+// A Network describes a Chia network; where its files are kept, which ports its services use, and how its addresses and genesis challenge are recognized.
+type Network struct {
+	Name             string          // Network name, as in the config's selected_network.
+	AddressPrefix    string          // Prefix of the network's addresses, such as "xch".
+	GenesisChallenge string          // Genesis challenge in hex, without 0x. If empty, it isn't checked.
+	Dir              string          // Root directory, relative to DefaultPath.
+	Ports            map[string]uint // RPC ports by service name, where they differ from a stock install.
+}
+
+// Networks known to the package. A custom network can be described by a new *Network, or read from the Chia config; see Config.Network.
+var (
+	Mainnet = &Network{
+		Name:             "mainnet",
+		AddressPrefix:    "xch",
+		GenesisChallenge: "ccd5bb71183532bff220ba46c268991a3ff07eb358e8255a65c30a2dce0e5fbb",
+		Dir:              "mainnet",
+	}
+	Testnet11 = &Network{
+		Name:             "testnet11",
+		AddressPrefix:    "txch",
+		GenesisChallenge: "37a90eb5185a9c4439a91ddc98bbadce7b4feba060d50116a067de66bf236615",
+		Dir:              "testnet11",
+	}
+	// Simulator is the network of a simulator created by "chia dev sim create". Each simulator has its own genesis challenge, so it isn't checked.
+	Simulator = &Network{
+		Name:          "simulator0",
+		AddressPrefix: "txch",
+		Dir:           "simulator/main",
+	}
+)
+
+// LookupNetwork returns the known Network with the given name, and whether there is one.
+func LookupNetwork(name string) (*Network, bool) {
+	for _, n := range []*Network{Mainnet, Testnet11, Simulator} {
+		if n.Name == name {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// String returns the network's name. Implements the fmt.Stringer interface.
+func (n *Network) String() string {
+	return n.Name
+}
+
+// Root returns the network's Chia root directory; the value of CHIA_ROOT if set, otherwise the network's Dir under DefaultPath in the user's home directory.
+func (n *Network) Root() (string, error) {
+	if r := os.Getenv(RootEnv); r != "" {
+		return expandHome(r)
+	}
+	h, err := homeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(h, DefaultPath, n.dir()), nil
+}
+
+// dir returns the network's Dir, or that of Mainnet if it has none.
+func (n *Network) dir() string {
+	if n == nil || n.Dir == "" {
+		return Mainnet.Dir
+	}
+	return n.Dir
+}
+
+// certDir returns the default certificate directory of the network, under its Root. Without CHIA_ROOT, DefaultCertPath is used for the mainnet directory, so that it can still be overridden.
+func (n *Network) certDir() (string, error) {
+	r, err := n.Root()
+	if err != nil {
+		return "", err
+	}
+	if n.dir() == Mainnet.Dir && os.Getenv(RootEnv) == "" {
+		h, err := homeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(h, DefaultPath, DefaultCertPath), nil
+	}
+	return filepath.Join(r, "config", "ssl"), nil
+}
+
+// Port returns the network's RPC port for the named service.
+func (n *Network) Port(service string) uint {
+	if n != nil {
+		if p, ok := n.Ports[service]; ok {
+			return p
+		}
+	}
+	return defaultPorts[service]
+}
+
+// addressPrefixes returns the prefixes recognized as addresses when checking requests; those of the known networks, and the network's own.
+func (n *Network) addressPrefixes() map[string]bool {
+	ps := map[string]bool{Mainnet.AddressPrefix: true, Testnet11.AddressPrefix: true, Simulator.AddressPrefix: true}
+	if n.AddressPrefix != "" {
+		ps[n.AddressPrefix] = true
+	}
+	return ps
+}
+
+// CheckAddress returns an error wrapping ErrWrongNetwork if addr is not an address of the network.
+func (n *Network) CheckAddress(addr string) error {
+	p, _, err := DecodeAddress(addr)
+	if err != nil {
+		return err
+	}
+	if n.AddressPrefix != "" && p != n.AddressPrefix {
+		return fmt.Errorf("Address %s has prefix %q, but %s addresses have prefix %q: %w", addr, p, n, n.AddressPrefix, ErrWrongNetwork)
+	}
+	return nil
+}
+
+// CheckRequest returns an error wrapping ErrWrongNetwork if the JSON request body j contains an address of another network. Any string with a known address prefix, which decodes as an address, is checked, wherever it appears.
+func (n *Network) CheckRequest(j []byte) error {
+	if n.AddressPrefix == "" || len(j) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(j, &v); err != nil {
+		// Not for us to judge; the service will reject it.
+		return nil
+	}
+	return n.checkValue(v, n.addressPrefixes())
+}
+
+// checkValue does the work of CheckRequest, for a decoded JSON value.
+func (n *Network) checkValue(v any, ps map[string]bool) error {
+	switch v := v.(type) {
+	case string:
+		i := strings.LastIndexByte(v, '1')
+		if i < 1 || !ps[strings.ToLower(v[:i])] {
+			return nil
+		}
+		if _, _, err := DecodeAddress(v); err != nil {
+			return nil
+		}
+		return n.CheckAddress(v)
+	case []any:
+		for _, e := range v {
+			if err := n.checkValue(e, ps); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, e := range v {
+			if err := n.checkValue(e, ps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Verify returns an error wrapping ErrWrongNetwork if the network info reported by a service doesn't match the network. Fields either side leaves empty aren't compared.
+func (n *Network) Verify(info *NetworkInfoResponse) error {
+	if n.Name != "" && info.NetworkName != "" && info.NetworkName != n.Name {
+		return fmt.Errorf("Service is on network %q, not %q: %w", info.NetworkName, n.Name, ErrWrongNetwork)
+	}
+	if n.AddressPrefix != "" && info.NetworkPrefix != "" && info.NetworkPrefix != n.AddressPrefix {
+		return fmt.Errorf("Service uses address prefix %q, not %q: %w", info.NetworkPrefix, n.AddressPrefix, ErrWrongNetwork)
+	}
+	g := strings.TrimPrefix(info.GenesisChallenge, "0x")
+	if n.GenesisChallenge != "" && g != "" && !strings.EqualFold(g, strings.TrimPrefix(n.GenesisChallenge, "0x")) {
+		return fmt.Errorf("Service has genesis challenge %s, not %s of %s: %w", g, n.GenesisChallenge, n, ErrWrongNetwork)
+	}
+	return nil
+}
+
+// VerifyNetwork asks the full node or wallet service behind c for its network info, and checks it against n. See Network.Verify.
+func VerifyNetwork(ctx context.Context, c Caller, n *Network) error {
+	info, err := new(NetworkInfoRequest).SendContext(ctx, c)
+	if err != nil {
+		return fmt.Errorf("Couldn't get network info. Error: %w", err)
+	}
+	return n.Verify(info)
+}
+
+// NetworkInfoRequest is a type for making a request for the network of a full node or wallet service.
+type NetworkInfoRequest struct{}
+
+// Procedure returns the Procedure which this request will use.
+func (n *NetworkInfoRequest) Procedure() Procedure {
+	return GetNetworkInfo
+}
+
+// Send sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (n *NetworkInfoRequest) Send(e *Endpoint) (*NetworkInfoResponse, error) {
+	return n.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (n *NetworkInfoRequest) SendContext(ctx context.Context, caller Caller) (*NetworkInfoResponse, error) {
+	return Do[*NetworkInfoRequest, NetworkInfoResponse](ctx, caller, n)
+}
+
+// String implements the fmt.Stringer interface.
+func (n *NetworkInfoRequest) String() string {
+	return requestString(n)
+}
+
+// NetworkInfoResponse represents the Chia RPC API's response to a NetworkInfoRequest.
+type NetworkInfoResponse struct {
+	NetworkName      string `json:"network_name"`
+	NetworkPrefix    string `json:"network_prefix"`
+	GenesisChallenge string `json:"genesis_challenge,omitempty"` // Reported by recent versions of Chia only.
+	Response
+}
@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testAddress(t *testing.T, prefix string) string {
+	t.Helper()
+	a, err := EncodeAddress(prefix, strings.Repeat("12", 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestNetworkCheckRequest(t *testing.T) {
+	xch, txch := testAddress(t, "xch"), testAddress(t, "txch")
+	if err := Testnet11.CheckRequest([]byte(`{"wallet_id": 1, "address": "` + txch + `"}`)); err != nil {
+		t.Errorf("Testnet address refused: %s", err)
+	}
+	// Addresses are found wherever they are, including in lists.
+	err := Testnet11.CheckRequest([]byte(`{"targets": [{"address": "` + xch + `"}]}`))
+	if !errors.Is(err, ErrWrongNetwork) {
+		t.Errorf("Mainnet address on testnet: %v", err)
+	}
+	// Other bech32m strings, such as DIDs and NFT IDs, aren't addresses.
+	if err := Mainnet.CheckRequest([]byte(`{"did_id": "did:chia:1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqlq6ugh", "nft_id": "nft1abc"}`)); err != nil {
+		t.Errorf("Non-address refused: %s", err)
+	}
+}
+
+func TestNetworkCertDir(t *testing.T) {
+	h := t.TempDir()
+	defer func(d string) { HomeDir = d }(HomeDir)
+	HomeDir = h
+	t.Setenv(RootEnv, "")
+	if d, err := Mainnet.certDir(); err != nil || d != filepath.Join(h, DefaultPath, DefaultCertPath) {
+		t.Errorf("Mainnet cert directory %q, %v", d, err)
+	}
+	if d, err := Testnet11.certDir(); err != nil || d != filepath.Join(h, DefaultPath, "testnet11", "config", "ssl") {
+		t.Errorf("Testnet cert directory %q, %v", d, err)
+	}
+	// Without a config, CHIA_ROOT still locates the certificates.
+	r := t.TempDir()
+	t.Setenv(RootEnv, r)
+	for _, n := range []*Network{Mainnet, Testnet11} {
+		if d, err := n.certDir(); err != nil || d != filepath.Join(r, "config", "ssl") {
+			t.Errorf("%s cert directory %q, %v", n, d, err)
+		}
+	}
+	e := &Endpoint{Name: ServiceFullNode}
+	if err := e.fill(); err != nil {
+		t.Fatal(err)
+	}
+	if e.CACertPath != filepath.Join(r, "config", "ssl", "ca", "private_ca.crt") {
+		t.Errorf("CA certificate path %q", e.CACertPath)
+	}
+}
+
+func TestConfigNetwork(t *testing.T) {
+	cfg, err := ParseConfig(testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Network() != Mainnet {
+		t.Errorf("Expected Mainnet, got %+v", cfg.Network())
+	}
+	cfg, err = ParseConfig([]byte(`
+selected_network: devnet
+network_overrides:
+  config:
+    devnet:
+      address_prefix: dxch
+  constants:
+    devnet:
+      GENESIS_CHALLENGE: 0xabcd
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := cfg.Network()
+	if n.Name != "devnet" || n.AddressPrefix != "dxch" || n.GenesisChallenge != "abcd" || n.Dir != "" {
+		t.Errorf("Unexpected network %+v", n)
+	}
+}
+
+func TestVerifyNetwork(t *testing.T) {
+	c := &cannedCaller{body: []byte(`{"success": true, "network_name": "testnet11", "network_prefix": "txch", "genesis_challenge": "0x37a90eb5185a9c4439a91ddc98bbadce7b4feba060d50116a067de66bf236615"}`)}
+	if err := VerifyNetwork(context.Background(), c, Testnet11); err != nil {
+		t.Errorf("Testnet11 not verified: %s", err)
+	}
+	if c.procedure != GetNetworkInfo {
+		t.Errorf("Called %s", c.procedure)
+	}
+	if err := VerifyNetwork(context.Background(), c, Mainnet); !errors.Is(err, ErrWrongNetwork) {
+		t.Errorf("Mainnet verified against testnet11: %v", err)
+	}
+	custom := &Network{Name: "testnet11", AddressPrefix: "txch", GenesisChallenge: strings.Repeat("00", 32)}
+	if err := VerifyNetwork(context.Background(), c, custom); !errors.Is(err, ErrWrongNetwork) {
+		t.Errorf("Genesis challenge not checked: %v", err)
+	}
+}
+
+func TestEndpointRefusesForeignAddress(t *testing.T) {
+	called := false
+	e := newTestServer(t, newTestCA(t), func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Write([]byte(`{"success": true}`))
+	})
+	e.Network = Testnet11
+	_, err := e.Call("send_transaction", []byte(`{"address": "`+testAddress(t, "xch")+`", "amount": 1}`))
+	if !errors.Is(err, ErrWrongNetwork) || called {
+		t.Errorf("Mainnet address sent to a testnet wallet: %v", err)
+	}
+}
+
+func TestNewClientNetworkMismatch(t *testing.T) {
+	cfg, err := ParseConfig(testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClient(WithConfig(cfg), WithNetwork(Testnet11)); !errors.Is(err, ErrWrongNetwork) {
+		t.Errorf("Expected ErrWrongNetwork, got %v", err)
+	}
+	c, err := NewClient(WithConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Network() != Mainnet {
+		t.Errorf("Expected Mainnet, got %v", c.Network())
+	}
+}
+
+func TestClientEndpointNetwork(t *testing.T) {
+	cfg, err := ParseConfig(testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A config selecting no network is accepted for any.
+	cfg.SelectedNetwork = ""
+	cfg.Root = t.TempDir()
+	c, err := NewClient(WithConfig(cfg), WithNetwork(Testnet11))
+	if err != nil {
+		t.Fatalf("NewClient failed: %s", err)
+	}
+	// There are no certificates, so initialization fails, but the endpoint is made.
+	c.Wallet()
+	e := c.endpoints[ServiceWallet]
+	if e == nil || e.Network != Testnet11 {
+		t.Fatalf("Wallet endpoint not on testnet: %+v", e)
+	}
+	if err := e.Network.CheckRequest([]byte(`{"address": "` + testAddress(t, "txch") + `"}`)); err != nil {
+		t.Errorf("Testnet address refused: %s", err)
+	}
+}
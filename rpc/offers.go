@@ -0,0 +1,478 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OfferPrefix is the human readable part of bech32m encoded offers.
+const OfferPrefix = "offer"
+
+// XCHAsset is the key of XCH among the assets of an offer.
+const XCHAsset = "xch"
+
+// An Offer is an offer file's contents; a bech32m encoded, compressed spend bundle, beginning "offer1". Its assets are reported by the wallet service; see Summary.
+type Offer struct {
+	text string
+	data []byte
+}
+
+// ParseOffer returns the Offer encoded in s, ignoring surrounding whitespace.
+func ParseOffer(s string) (*Offer, error) {
+	s = strings.TrimSpace(s)
+	hrp, data, err := Bech32mDecode(s)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid offer. Error: %w", err)
+	}
+	if hrp != OfferPrefix {
+		return nil, fmt.Errorf("Invalid offer; prefix %q, not %q.", hrp, OfferPrefix)
+	}
+	return &Offer{text: strings.ToLower(s), data: data}, nil
+}
+
+// NewOffer returns the Offer of the compressed spend bundle data.
+func NewOffer(data []byte) (*Offer, error) {
+	s, err := Bech32mEncode(OfferPrefix, data)
+	if err != nil {
+		return nil, err
+	}
+	return &Offer{text: s, data: data}, nil
+}
+
+// LoadOffer reads the offer file at path.
+func LoadOffer(path string) (*Offer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read offer file. Error: %w", err)
+	}
+	return ParseOffer(string(b))
+}
+
+// Save writes the offer to an offer file at path.
+func (o *Offer) Save(path string) error {
+	if err := os.WriteFile(path, []byte(o.text), 0o644); err != nil {
+		return fmt.Errorf("Couldn't write offer file. Error: %w", err)
+	}
+	return nil
+}
+
+// String returns the offer's text. Implements the fmt.Stringer interface.
+func (o *Offer) String() string {
+	return o.text
+}
+
+// Bytes returns the offer's compressed spend bundle.
+func (o *Offer) Bytes() []byte {
+	return o.data
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding the offer as its text.
+func (o *Offer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.text)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, decoding offer text.
+func (o *Offer) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	p, err := ParseOffer(s)
+	if err != nil {
+		return err
+	}
+	*o = *p
+	return nil
+}
+
+// Summary asks the wallet service behind c what the offer offers and requests.
+func (o *Offer) Summary(ctx context.Context, c Caller) (*OfferSummary, error) {
+	r, err := (&OfferSummaryRequest{Offer: o}).SendContext(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get offer summary. Error: %w", err)
+	}
+	return r.Summary, nil
+}
+
+// OfferSummary describes the assets of an offer, from the maker's view. Amounts are keyed by asset; XCHAsset for XCH, in mojos, otherwise the asset ID of a CAT, in its units, or the launcher ID of an NFT, in hex without a 0x prefix.
+type OfferSummary struct {
+	Offered    map[string]uint           `json:"offered"`
+	Requested  map[string]uint           `json:"requested"`
+	Fees       uint                      `json:"fees"`  // In mojos, paid by the maker.
+	Infos      map[string]map[string]any `json:"infos"` // Puzzle driver info of each asset other than XCH, such as the CAT's TAIL, or the NFT's royalties.
+	ValidTimes *ConditionValidTimes      `json:"valid_times,omitempty"`
+}
+
+// A Royalty is owed to an NFT's creator when the NFT is traded in an offer.
+type Royalty struct {
+	LauncherId string // The NFT's launcher ID.
+	PuzzleHash string // Puzzle hash of the royalty address.
+	Percentage uint   // In hundredths of a percent; 300 is 3%.
+	Asset      string // Asset the royalty is paid in.
+	Amount     uint   // Amount paid, in the asset's units.
+}
+
+// Royalties returns the royalties the offer implies; for each NFT with royalties, a share of each fungible asset given for it on the other side.
+func (s *OfferSummary) Royalties() []Royalty {
+	var rs []Royalty
+	rs = append(rs, s.royalties(s.Offered, s.Requested)...)
+	rs = append(rs, s.royalties(s.Requested, s.Offered)...)
+	return rs
+}
+
+// royalties returns the royalties of the NFTs among nfts, paid in the fungible assets among payment. As Chia does, each payment is split evenly among the NFTs with royalty transfer programs, and each NFT's royalty is its percentage of its share, rounding down.
+func (s *OfferSummary) royalties(nfts, payment map[string]uint) []Royalty {
+	var ids []string
+	for _, id := range sortedKeys(nfts) {
+		if _, ok := s.transferProgram(id); ok {
+			ids = append(ids, id)
+		}
+	}
+	var rs []Royalty
+	for _, id := range ids {
+		tp, _ := s.transferProgram(id)
+		pct, _ := strconv.ParseUint(fmt.Sprint(tp["royalty_percentage"]), 10, 64)
+		if pct == 0 {
+			continue
+		}
+		ph, _ := tp["royalty_address"].(string)
+		for _, asset := range sortedKeys(payment) {
+			if _, ok := s.transferProgram(asset); ok || s.isNFT(asset) {
+				continue
+			}
+			share := uint64(payment[asset]) / uint64(len(ids))
+			rs = append(rs, Royalty{LauncherId: id, PuzzleHash: ph, Percentage: uint(pct), Asset: asset, Amount: uint(share * pct / 10000)})
+		}
+	}
+	return rs
+}
+
+// isNFT reports whether the asset is a singleton, such as an NFT.
+func (s *OfferSummary) isNFT(asset string) bool {
+	t, _ := s.Infos[asset]["type"].(string)
+	return t == "singleton"
+}
+
+// transferProgram returns the royalty transfer program of an NFT among the summary's infos, and whether it has one.
+func (s *OfferSummary) transferProgram(asset string) (map[string]any, bool) {
+	info := s.Infos[asset]
+	// Driver info nests each outer puzzle's inner puzzle under "also".
+	for info != nil {
+		if tp, ok := info["transfer_program"].(map[string]any); ok {
+			return tp, true
+		}
+		info, _ = info["also"].(map[string]any)
+	}
+	return nil, false
+}
+
+func sortedKeys(m map[string]uint) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// Trade statuses of a TradeRecord.
+const (
+	TradePendingAccept  = "PENDING_ACCEPT"
+	TradePendingConfirm = "PENDING_CONFIRM"
+	TradePendingCancel  = "PENDING_CANCEL"
+	TradeCancelled      = "CANCELLED"
+	TradeConfirmed      = "CONFIRMED"
+	TradeFailed         = "FAILED"
+)
+
+// TradeRecord is a wallet's record of an offer it made or took.
+type TradeRecord struct {
+	TradeId          string               `json:"trade_id"`
+	Status           string               `json:"status"` // One of the Trade statuses, such as TradePendingAccept.
+	IsMyOffer        bool                 `json:"is_my_offer"`
+	CreatedAtTime    uint                 `json:"created_at_time"`
+	AcceptedAtTime   *uint                `json:"accepted_at_time"`
+	ConfirmedAtIndex uint                 `json:"confirmed_at_index"`
+	Sent             uint                 `json:"sent"`
+	SentTo           []SentTo             `json:"sent_to"`
+	CoinsOfInterest  []*Coin              `json:"coins_of_interest"`
+	Summary          *OfferSummary        `json:"summary"`
+	Pending          map[string]uint      `json:"pending"` // Amounts locked by the offer, by asset.
+	ValidTimes       *ConditionValidTimes `json:"valid_times,omitempty"`
+}
+
+// CreateOfferResponse represents the Chia RPC API's response to a CreateOfferRequest.
+type CreateOfferResponse struct {
+	Offer       *Offer       `json:"offer"`
+	TradeRecord *TradeRecord `json:"trade_record"`
+	Response
+}
+
+// CreateOfferRequest is a type for making a request to create an offer. Offer maps wallet IDs, or asset IDs, to amounts; negative for those offered, positive for those requested.
+type CreateOfferRequest struct {
+	Offer         map[string]int64 `json:"offer"`
+	Fee           uint             `json:"fee"`
+	DriverDict    map[string]any   `json:"driver_dict,omitempty"` // Puzzle driver info of requested assets the wallet doesn't know, such as NFTs, by asset ID.
+	ValidateOnly  bool             `json:"validate_only,omitempty"`
+	MinCoinAmount uint             `json:"min_coin_amount,omitempty"`
+	MaxCoinAmount uint             `json:"max_coin_amount,omitempty"`
+	Solver        map[string]any   `json:"solver,omitempty"`
+	ReusePuzHash  bool             `json:"reuse_puzhash,omitempty"`
+	MinTime       uint             `json:"min_time,omitempty"`
+	MaxTime       uint             `json:"max_time,omitempty"` // Unix time after which the offer expires.
+	MinHeight     uint             `json:"min_height,omitempty"`
+	MaxHeight     uint             `json:"max_height,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CreateOfferRequest) Procedure() Procedure {
+	return WalletCreateOfferForIds
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CreateOfferRequest) Send(e *Endpoint) (*CreateOfferResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CreateOfferRequest) SendContext(ctx context.Context, caller Caller) (*CreateOfferResponse, error) {
+	return Do[*CreateOfferRequest, CreateOfferResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CreateOfferRequest) String() string {
+	return requestString(c)
+}
+
+// OfferSummaryResponse represents the Chia RPC API's response to an OfferSummaryRequest.
+type OfferSummaryResponse struct {
+	Summary *OfferSummary `json:"summary"`
+	Id      string        `json:"id"` // The offer's ID.
+	Response
+}
+
+// OfferSummaryRequest is a type for making a request for the assets of an offer.
+type OfferSummaryRequest struct {
+	Offer    *Offer `json:"offer"`
+	Advanced bool   `json:"advanced,omitempty"` // Summarize the offer's coins, rather than its assets.
+}
+
+// Procedure returns the Procedure which this request will use.
+func (o *OfferSummaryRequest) Procedure() Procedure {
+	return WalletGetOfferSummary
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (o *OfferSummaryRequest) Send(e *Endpoint) (*OfferSummaryResponse, error) {
+	return o.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (o *OfferSummaryRequest) SendContext(ctx context.Context, caller Caller) (*OfferSummaryResponse, error) {
+	return Do[*OfferSummaryRequest, OfferSummaryResponse](ctx, caller, o)
+}
+
+// String implements the fmt.Stringer interface.
+func (o *OfferSummaryRequest) String() string {
+	return requestString(o)
+}
+
+// OfferValidityResponse represents the Chia RPC API's response to an OfferValidityRequest.
+type OfferValidityResponse struct {
+	Valid bool   `json:"valid"` // Whether the offer's coins are all unspent.
+	Id    string `json:"id"`
+	Response
+}
+
+// OfferValidityRequest is a type for making a request to check whether an offer can still be taken.
+type OfferValidityRequest struct {
+	Offer *Offer `json:"offer"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (o *OfferValidityRequest) Procedure() Procedure {
+	return WalletCheckOfferValidity
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (o *OfferValidityRequest) Send(e *Endpoint) (*OfferValidityResponse, error) {
+	return o.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (o *OfferValidityRequest) SendContext(ctx context.Context, caller Caller) (*OfferValidityResponse, error) {
+	return Do[*OfferValidityRequest, OfferValidityResponse](ctx, caller, o)
+}
+
+// String implements the fmt.Stringer interface.
+func (o *OfferValidityRequest) String() string {
+	return requestString(o)
+}
+
+// TradeRecordResponse represents the Chia RPC API's response to a TakeOfferRequest or OfferRequest.
+type TradeRecordResponse struct {
+	TradeRecord *TradeRecord `json:"trade_record"`
+	Offer       *Offer       `json:"offer,omitempty"` // For an OfferRequest with FileContents.
+	Response
+}
+
+// TakeOfferRequest is a type for making a request to take an offer. Fee is in mojos.
+type TakeOfferRequest struct {
+	Offer         *Offer         `json:"offer"`
+	Fee           uint           `json:"fee"`
+	MinCoinAmount uint           `json:"min_coin_amount,omitempty"`
+	MaxCoinAmount uint           `json:"max_coin_amount,omitempty"`
+	Solver        map[string]any `json:"solver,omitempty"`
+	ReusePuzHash  bool           `json:"reuse_puzhash,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (t *TakeOfferRequest) Procedure() Procedure {
+	return WalletTakeOffer
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (t *TakeOfferRequest) Send(e *Endpoint) (*TradeRecordResponse, error) {
+	return t.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (t *TakeOfferRequest) SendContext(ctx context.Context, caller Caller) (*TradeRecordResponse, error) {
+	return Do[*TakeOfferRequest, TradeRecordResponse](ctx, caller, t)
+}
+
+// String implements the fmt.Stringer interface.
+func (t *TakeOfferRequest) String() string {
+	return requestString(t)
+}
+
+// OfferRequest is a type for making a request for the trade record of an offer by trade ID.
+type OfferRequest struct {
+	TradeId      string `json:"trade_id"`
+	FileContents bool   `json:"file_contents,omitempty"` // Include the offer itself.
+}
+
+// Procedure returns the Procedure which this request will use.
+func (o *OfferRequest) Procedure() Procedure {
+	return WalletGetOffer
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (o *OfferRequest) Send(e *Endpoint) (*TradeRecordResponse, error) {
+	return o.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (o *OfferRequest) SendContext(ctx context.Context, caller Caller) (*TradeRecordResponse, error) {
+	return Do[*OfferRequest, TradeRecordResponse](ctx, caller, o)
+}
+
+// String implements the fmt.Stringer interface.
+func (o *OfferRequest) String() string {
+	return requestString(o)
+}
+
+// AllOffersResponse represents the Chia RPC API's response to an AllOffersRequest.
+type AllOffersResponse struct {
+	TradeRecords []*TradeRecord `json:"trade_records"`
+	Offers       []*Offer       `json:"offers,omitempty"` // For FileContents, the offer of each trade record, or nil.
+	Response
+}
+
+// AllOffersRequest is a type for making a request for a page of the wallet's offers, from index Start to End, exclusive.
+type AllOffersRequest struct {
+	Start              uint   `json:"start"`
+	End                uint   `json:"end,omitempty"` // If zero, the node's default page size is used.
+	SortKey            string `json:"sort_key,omitempty"`
+	Reverse            bool   `json:"reverse,omitempty"`
+	FileContents       bool   `json:"file_contents,omitempty"`
+	ExcludeMyOffers    bool   `json:"exclude_my_offers,omitempty"`
+	ExcludeTakenOffers bool   `json:"exclude_taken_offers,omitempty"`
+	IncludeCompleted   bool   `json:"include_completed,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (a *AllOffersRequest) Procedure() Procedure {
+	return WalletGetAllOffers
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (a *AllOffersRequest) Send(e *Endpoint) (*AllOffersResponse, error) {
+	return a.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (a *AllOffersRequest) SendContext(ctx context.Context, caller Caller) (*AllOffersResponse, error) {
+	return Do[*AllOffersRequest, AllOffersResponse](ctx, caller, a)
+}
+
+// String implements the fmt.Stringer interface.
+func (a *AllOffersRequest) String() string {
+	return requestString(a)
+}
+
+// CancelOfferResponse represents the Chia RPC API's response to a CancelOfferRequest or CancelOffersRequest.
+type CancelOfferResponse struct {
+	Response
+}
+
+// CancelOfferRequest is a type for making a request to cancel an offer. A secure cancellation spends the offered coins, for Fee, in mojos, so that the offer can't be taken; otherwise the offer is only forgotten by the wallet.
+type CancelOfferRequest struct {
+	TradeId string `json:"trade_id"`
+	Secure  bool   `json:"secure"`
+	Fee     uint   `json:"fee,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CancelOfferRequest) Procedure() Procedure {
+	return WalletCancelOffer
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CancelOfferRequest) Send(e *Endpoint) (*CancelOfferResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CancelOfferRequest) SendContext(ctx context.Context, caller Caller) (*CancelOfferResponse, error) {
+	return Do[*CancelOfferRequest, CancelOfferResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CancelOfferRequest) String() string {
+	return requestString(c)
+}
+
+// CancelOffersRequest is a type for making a request to cancel the wallet's pending offers; all of them, or those offering the asset with AssetId. See CancelOfferRequest.
+type CancelOffersRequest struct {
+	Secure    bool   `json:"secure"`
+	BatchFee  uint   `json:"batch_fee,omitempty"` // Fee, in mojos, for each batch of secure cancellations.
+	BatchSize uint   `json:"batch_size,omitempty"`
+	CancelAll bool   `json:"cancel_all,omitempty"`
+	AssetId   string `json:"asset_id,omitempty"` // XCHAsset, or a CAT's asset ID.
+}
+
+// Procedure returns the Procedure which this request will use.
+func (c *CancelOffersRequest) Procedure() Procedure {
+	return WalletCancelOffers
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (c *CancelOffersRequest) Send(e *Endpoint) (*CancelOfferResponse, error) {
+	return c.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (c *CancelOffersRequest) SendContext(ctx context.Context, caller Caller) (*CancelOfferResponse, error) {
+	return Do[*CancelOffersRequest, CancelOfferResponse](ctx, caller, c)
+}
+
+// String implements the fmt.Stringer interface.
+func (c *CancelOffersRequest) String() string {
+	return requestString(c)
+}
@@ -0,0 +1,103 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+	"github.com/Jsewill/chia/rpc/rpctest"
+)
+
+func TestOfferFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drop.offer")
+	if err := os.WriteFile(path, []byte(rpctest.FixtureOffer+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	o, err := rpc.LoadOffer(path)
+	if err != nil {
+		t.Fatalf("LoadOffer failed: %s", err)
+	}
+	if o.String() != rpctest.FixtureOffer || !strings.HasPrefix(string(o.Bytes()), "rpctest fixture offer") {
+		t.Errorf("Unexpected offer %s, %q", o, o.Bytes())
+	}
+	again := filepath.Join(t.TempDir(), "again.offer")
+	if err := o.Save(again); err != nil {
+		t.Fatal(err)
+	}
+	if o2, err := rpc.LoadOffer(again); err != nil || o2.String() != o.String() {
+		t.Errorf("Offer didn't round trip: %s, %v", o2, err)
+	}
+	if n, err := rpc.NewOffer(o.Bytes()); err != nil || n.String() != o.String() {
+		t.Errorf("NewOffer gave %s, %v", n, err)
+	}
+	// Addresses aren't offers.
+	if _, err := rpc.ParseOffer("xch1f0ryxk6qn096hefcwrdwpuph2hm24w69jnzezhkfswk0z2jar7aq5zzpfj"); err == nil {
+		t.Error("Expected an error for an address")
+	}
+}
+
+func TestOffers(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	w := s.Endpoint()
+	ctx := context.Background()
+	cr, err := (&rpc.CreateOfferRequest{Offer: map[string]int64{"4": -1, "1": 1000000000000}, Fee: 100000}).SendContext(ctx, w)
+	if err != nil || cr.Offer.String() != rpctest.FixtureOffer || cr.TradeRecord.Status != rpc.TradePendingAccept {
+		t.Fatalf("Unexpected created offer: %+v, %v", cr, err)
+	}
+	if cs := s.CallsTo(rpc.WalletCreateOfferForIds); len(cs) != 1 || string(cs[0].Request) != `{"offer":{"1":1000000000000,"4":-1},"fee":100000}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	sum, err := cr.Offer.Summary(ctx, w)
+	if err != nil || sum.Requested[rpc.XCHAsset] != 1000000000000 || sum.Fees != 100000 {
+		t.Fatalf("Unexpected summary: %+v, %v", sum, err)
+	}
+	if cs := s.CallsTo(rpc.WalletGetOfferSummary); len(cs) != 1 || string(cs[0].Request) != `{"offer":"`+rpctest.FixtureOffer+`"}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	rs := sum.Royalties()
+	if len(rs) != 1 || rs[0].LauncherId != rpctest.FixtureLauncherId || rs[0].Percentage != 300 || rs[0].Asset != rpc.XCHAsset || rs[0].Amount != 30000000000 || rs[0].PuzzleHash != rpctest.FixturePuzzleHash {
+		t.Errorf("Unexpected royalties: %+v", rs)
+	}
+	if v, err := (&rpc.OfferValidityRequest{Offer: cr.Offer}).SendContext(ctx, w); err != nil || !v.Valid {
+		t.Errorf("Unexpected validity: %+v, %v", v, err)
+	}
+	if tr, err := (&rpc.TakeOfferRequest{Offer: cr.Offer, Fee: 100000}).SendContext(ctx, w); err != nil || tr.TradeRecord.TradeId != rpctest.FixtureTradeId {
+		t.Errorf("Unexpected take: %+v, %v", tr, err)
+	}
+	all, err := (&rpc.AllOffersRequest{End: 10, FileContents: true}).SendContext(ctx, w)
+	if err != nil || len(all.TradeRecords) != 1 || len(all.Offers) != 1 || all.Offers[0].String() != rpctest.FixtureOffer {
+		t.Errorf("Unexpected offers: %+v, %v", all, err)
+	}
+	if _, err := (&rpc.CancelOfferRequest{TradeId: rpctest.FixtureTradeId, Secure: true, Fee: 100000}).SendContext(ctx, w); err != nil {
+		t.Errorf("Cancel Offer Request failed: %s", err)
+	}
+	if _, err := (&rpc.CancelOffersRequest{Secure: false, CancelAll: true}).SendContext(ctx, w); err != nil {
+		t.Errorf("Cancel Offers Request failed: %s", err)
+	}
+}
+
+func TestOfferRoyaltiesSplit(t *testing.T) {
+	nft := func(launcher, pct string) string {
+		return `{"type": "singleton", "launcher_id": "0x` + launcher + `", "also": {"type": "metadata", "also": {"type": "ownership",
+			"transfer_program": {"type": "royalty transfer program", "royalty_address": "` + rpctest.FixturePuzzleHash + `", "royalty_percentage": "` + pct + `"}}}}`
+	}
+	a, b := strings.Repeat("aa", 32), strings.Repeat("bb", 32)
+	var sum rpc.OfferSummary
+	err := json.Unmarshal([]byte(`{
+		"offered": {"`+a+`": 1, "`+b+`": 1},
+		"requested": {"xch": 1000000000001},
+		"infos": {"`+a+`": `+nft(a, "300")+`, "`+b+`": `+nft(b, "500")+`}
+	}`), &sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The payment is split between the NFTs before each royalty is taken from its share.
+	rs := sum.Royalties()
+	if len(rs) != 2 || rs[0].LauncherId != a || rs[0].Amount != 15000000000 || rs[1].LauncherId != b || rs[1].Amount != 25000000000 {
+		t.Errorf("Unexpected royalties: %+v", rs)
+	}
+}
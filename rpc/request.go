@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -13,17 +14,71 @@ func (p Procedure) String() string {
 	return string(p)
 }
 
-// Caller is implemented by any type that calls a Chia RPC endpoint.
+// Caller is implemented by any type that calls a Chia RPC endpoint. The Context governs cancellation and deadlines of the call.
 type Caller interface {
-	Call(Procedure, []byte) ([]byte, error)
+	CallContext(context.Context, Procedure, []byte) ([]byte, error)
 }
 
-// Sender is implemented by any type which can make a request via an Endpoint.
-type Sender interface {
-	Send(e *Endpoint) (interface{}, error)
+// Request is implemented by every request type. Its Procedure is the one the request is sent to.
+type Request interface {
 	Procedure() Procedure
 }
 
+// Sender is implemented by every typed request. Resp is the type of its response.
+type Sender[Resp any] interface {
+	Request
+	SendContext(context.Context, Caller) (Resp, error)
+}
+
+// Send sends s via c, with a Context, and returns its response. The response type is inferred from s, which makes Send convenient for generic tooling.
+func Send[Resp any](ctx context.Context, c Caller, s Sender[Resp]) (Resp, error) {
+	return s.SendContext(ctx, c)
+}
+
+// Do marshals req as JSON, calls its Procedure via c, and unmarshals the result into a new Resp. It is the core of every typed request's SendContext.
+// If the call fails with "success": false, or a non-2xx HTTP status, the error is an *APIError, and the response is returned along with it whenever it can be unmarshaled.
+func Do[Req Request, Resp any](ctx context.Context, c Caller, req Req) (*Resp, error) {
+	// Marshal request body as JSON
+	j, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	// Make request
+	out, err := c.CallContext(ctx, req.Procedure(), j)
+	if _, ok := AsAPIError(err); err != nil && !ok {
+		return nil, err
+	}
+	if err == nil {
+		// Not every Caller checks the response, so make sure.
+		var u string
+		if s, ok := c.(fmt.Stringer); ok {
+			u = s.String()
+		}
+		err = CheckResponse(req.Procedure(), u, 0, out)
+	}
+	// Handle response
+	r := new(Resp)
+	if uerr := json.Unmarshal(out, r); uerr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, uerr
+	}
+	return r, err
+}
+
+// requestString formats a request as its Procedure followed by its quoted JSON, with secrets such as mnemonics redacted; see Redact. Used by the String methods of request types.
+func requestString(r Request) string {
+	j, _ := json.Marshal(r)
+	return fmt.Sprintf(`%s %q`, r.Procedure(), Redact(j))
+}
+
+// Response holds the fields common to every Chia RPC response, and is embedded in every typed response.
+type Response struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
 // Generic response type for use in conjunction with UntypedRequest.
 type UntypedResponse map[string]interface{}
 
@@ -48,35 +103,29 @@ func (u *UntypedRequest) Procedure() Procedure {
 	return u.Proc
 }
 
+// MarshalJSON implements the json.Marshaler interface. Only Data is sent as the request body.
+func (u *UntypedRequest) MarshalJSON() ([]byte, error) {
+	if u.Data == nil {
+		return []byte(`{}`), nil
+	}
+	return json.Marshal(u.Data)
+}
+
 // Send sends the request via an Endpoint, and returns the result as an UntypedResponse, or nil, and an error. If successful, error returns nil.
 func (u *UntypedRequest) Send(e *Endpoint) (UntypedResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(u.Data)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(u.Proc, j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	ur := make(UntypedResponse)
-	err = json.Unmarshal(out, ur)
-	if err != nil {
-		logErr.Println(err)
+	return u.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil. If the error is an *APIError, the response is returned along with it whenever it can be unmarshaled.
+func (u *UntypedRequest) SendContext(ctx context.Context, caller Caller) (UntypedResponse, error) {
+	ur, err := Do[*UntypedRequest, UntypedResponse](ctx, caller, u)
+	if ur == nil {
 		return nil, err
 	}
-	return UntypedResponse(ur), nil
+	return *ur, err
 }
 
 // String implements the fmt.Stringer interface.
 func (u *UntypedRequest) String() string {
-	j, err := json.Marshal(u.Data)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, u.Proc, j)
+	return requestString(u)
 }
@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// Every typed request must genuinely implement Sender.
+var (
+	_ Sender[*MintBulkResponse]        = (*MintBulkRequest)(nil)
+	_ Sender[*MintResponse]            = (*MintRequest)(nil)
+	_ Sender[*SyncStatusResponse]      = (*SyncStatusRequest)(nil)
+	_ Sender[*WalletBalanceResponse]   = (*WalletBalanceRequest)(nil)
+	_ Sender[*NftWalletGetDidResponse] = (*NftWalletGetDidRequest)(nil)
+	_ Sender[*CoinRecordResponse]      = (*CoinRecordRequest)(nil)
+	_ Sender[*CoinRecordsResponse]     = (*CoinRecordsRequest)(nil)
+	_ Sender[*CoinRecordsResponse]     = (*CoinRecordsByNameRequest)(nil)
+	_ Sender[*CoinRecordsResponse]     = (*CoinRecordsByParentIdsRequest)(nil)
+	_ Sender[*PushTxResponse]          = (*PushTxRequest)(nil)
+	_ Sender[UntypedResponse]          = (*UntypedRequest)(nil)
+)
+
+// cannedCaller answers every call with the same body, and remembers the last call it received.
+type cannedCaller struct {
+	body      []byte
+	procedure Procedure
+	request   []byte
+}
+
+func (c *cannedCaller) CallContext(ctx context.Context, p Procedure, j []byte) ([]byte, error) {
+	c.procedure, c.request = p, j
+	return c.body, nil
+}
+
+func TestDo(t *testing.T) {
+	c := &cannedCaller{body: []byte(`{"success": true, "wallet_balance": {"wallet_id": 2, "confirmed_wallet_balance": 1000}}`)}
+	r, err := Send(context.Background(), c, &WalletBalanceRequest{WalletId: 2})
+	if err != nil {
+		t.Fatalf("Send failed: %s", err)
+	}
+	if c.procedure != WalletGetBalance || string(c.request) != `{"wallet_id":2}` {
+		t.Errorf("Called %s with %s", c.procedure, c.request)
+	}
+	if !r.Success || r.WalletBalance.ConfirmedWalletBalance != 1000 {
+		t.Errorf("Unexpected response: %+v", r)
+	}
+}
+
+func TestUntypedRequest(t *testing.T) {
+	c := &cannedCaller{body: []byte(`{"success": true, "synced": true}`)}
+	u := NewUntypedRequest(WalletSyncStatus)
+	u.Data["a"] = 1
+	r, err := u.SendContext(context.Background(), c)
+	if err != nil {
+		t.Fatalf("SendContext failed: %s", err)
+	}
+	if string(c.request) != `{"a":1}` {
+		t.Errorf("Sent %s", c.request)
+	}
+	if synced, _ := r["synced"].(bool); !synced {
+		t.Errorf("Unexpected response: %v", r)
+	}
+	if s := u.String(); s != `get_sync_status "{\"a\":1}"` {
+		t.Errorf("String returned %s", s)
+	}
+	// An unsuccessful response is returned with its *APIError.
+	c.body = []byte(`{"success": false, "error": "not synced", "synced": false}`)
+	r, err = u.SendContext(context.Background(), c)
+	if _, ok := AsAPIError(err); !ok || r == nil || r["error"] != "not synced" {
+		t.Errorf("Expected the response with an *APIError, got %v, %v", r, err)
+	}
+	if _, err := json.Marshal(&UntypedRequest{Proc: WalletSyncStatus}); err != nil {
+		t.Errorf("Marshal without Data failed: %s", err)
+	}
+}
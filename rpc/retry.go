@@ -0,0 +1,218 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// NonIdempotent holds the procedures which must not be retried once their request may have reached the service, since repeating them could, for example, spend or mint twice.
+// They are still retried when the connection could not be made at all.
+var NonIdempotent = map[Procedure]bool{
+	FullNodePushTx:          true, // Also WalletPushTx.
+	WalletNFTMint:           true,
+	WalletNFTMintBulk:       true,
+	WalletSendTransaction:   true,
+	WalletCATSpend:          true,
+	WalletCreateNewWallet:   true, // Issuing a CAT spends.
+	WalletCreateOfferForIds: true,
+	WalletTakeOffer:         true,
+	WalletCancelOffer:       true,
+	WalletCancelOffers:      true,
+}
+
+// A RetryPolicy describes how failed calls are retried, with exponential backoff and jitter.
+type RetryPolicy struct {
+	MaxAttempts    int                  // Total number of attempts, including the first. If less than 2, calls are not retried.
+	InitialBackoff time.Duration        // Wait before the first retry.
+	MaxBackoff     time.Duration        // Limit on the wait between attempts. If zero, there is no limit.
+	Multiplier     float64              // Factor by which the wait grows after each retry. If less than 1, the wait doesn't grow.
+	Jitter         float64              // Fraction, between 0 and 1, of each wait which is randomized.
+	Idempotent     func(Procedure) bool // Reports whether a procedure may be retried after its request may have reached the service. If nil, procedures not in NonIdempotent are.
+}
+
+// DefaultRetryPolicy is a reasonable RetryPolicy for calls to a local Chia service.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// Do calls f until it succeeds, returns an error which shouldn't be retried for procedure p, the attempts run out, or ctx is done; and returns the last error.
+func (rp *RetryPolicy) Do(ctx context.Context, p Procedure, f func() error) error {
+	var err error
+	for a := 1; ; a++ {
+		if err = f(); err == nil || a >= rp.MaxAttempts || !rp.Retryable(p, err) || ctx.Err() != nil {
+			return err
+		}
+		t := time.NewTimer(rp.Backoff(a))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
+
+// Backoff returns the wait after attempt number a, counting from 1.
+func (rp *RetryPolicy) Backoff(a int) time.Duration {
+	d := float64(rp.InitialBackoff)
+	if rp.Multiplier > 1 {
+		for i := 1; i < a; i++ {
+			d *= rp.Multiplier
+			if rp.MaxBackoff > 0 && d > float64(rp.MaxBackoff) {
+				break
+			}
+		}
+	}
+	if rp.MaxBackoff > 0 && d > float64(rp.MaxBackoff) {
+		d = float64(rp.MaxBackoff)
+	}
+	if rp.Jitter > 0 {
+		j := rp.Jitter
+		if j > 1 {
+			j = 1
+		}
+		d = d*(1-j) + d*j*rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// Retryable reports whether a call to procedure p which failed with err may be retried.
+// Failures to connect may always be retried. Timeouts, broken connections and 5xx HTTP statuses may be retried for idempotent procedures only. Anything else, including "success": false, may not.
+func (rp *RetryPolicy) Retryable(p Procedure, err error) bool {
+	switch {
+	case err == nil, errors.Is(err, ErrCircuitOpen), errors.Is(err, context.Canceled):
+		return false
+	case isDialError(err):
+		return true
+	}
+	idempotent := !NonIdempotent[p]
+	if rp.Idempotent != nil {
+		idempotent = rp.Idempotent(p)
+	}
+	if !idempotent {
+		return false
+	}
+	if e, ok := AsAPIError(err); ok {
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	// Anything else is a transport failure, such as a timeout or a reset connection.
+	return true
+}
+
+// isDialError reports whether err shows that a connection could not be made, so no request was sent.
+func isDialError(err error) bool {
+	var oe *net.OpError
+	return errors.As(err, &oe) && oe.Op == "dial"
+}
+
+// isServiceFailure reports whether err shows that the service is unreachable or unhealthy, rather than that it refused a particular request.
+func isServiceFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if e, ok := AsAPIError(err); ok {
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// ErrCircuitOpen is returned, without making a call, by an Endpoint whose CircuitBreaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open; service is failing")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Calls are allowed.
+	BreakerOpen                         // Calls fail fast with ErrCircuitOpen.
+	BreakerHalfOpen                     // A single trial call is allowed, to find out whether the service has recovered.
+)
+
+// String implements the fmt.Stringer interface.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// A CircuitBreaker fails calls fast while a service is down. It opens after FailureThreshold consecutive failures to reach the service, and after Cooldown lets a single trial call through, closing again if it succeeds.
+type CircuitBreaker struct {
+	FailureThreshold int           // Consecutive failures which open the breaker. If zero, 5 is used.
+	Cooldown         time.Duration // How long the breaker stays open before a trial call. If zero, 30 seconds is used.
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	opened   time.Time
+	trial    bool
+}
+
+// Allow returns ErrCircuitOpen if a call should not be made now, otherwise nil. Every allowed call must be followed by a call to Record.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.opened) >= b.cooldown() {
+		b.state = BreakerHalfOpen
+	}
+	switch b.state {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// Record records the outcome of an allowed call.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !isServiceFailure(err) {
+		b.state, b.failures = BreakerClosed, 0
+		return
+	}
+	b.failures++
+	threshold := b.FailureThreshold
+	if threshold == 0 {
+		threshold = 5
+	}
+	if b.state == BreakerHalfOpen || b.failures >= threshold {
+		b.state, b.opened = BreakerOpen, time.Now()
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.opened) >= b.cooldown() {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown == 0 {
+		return 30 * time.Second
+	}
+	return b.Cooldown
+}
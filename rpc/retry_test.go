@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+
+func TestEndpointRetry(t *testing.T) {
+	var calls atomic.Int32
+	e := newTestServer(t, newTestCA(t), func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1)%3 != 0 {
+			http.Error(w, "restarting", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success": true, "status": "SUCCESS"}`))
+	})
+	e.Retry = &testRetryPolicy
+
+	if _, err := e.Call(WalletSyncStatus, []byte(`{}`)); err != nil {
+		t.Errorf("Idempotent call wasn't retried to success: %s", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("Made %d attempts, expected 3", n)
+	}
+
+	// push_tx must not be repeated once the service may have received it.
+	calls.Store(0)
+	if _, err := e.Call(FullNodePushTx, []byte(`{}`)); err == nil {
+		t.Error("Non-idempotent call was retried")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Made %d attempts of a non-idempotent call, expected 1", n)
+	}
+}
+
+func TestEndpointCircuitBreaker(t *testing.T) {
+	e := newTestServer(t, newTestCA(t), nil)
+	// Point the endpoint at a port nothing listens on.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Port = uint(l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+	e.Retry = &testRetryPolicy
+	e.Breaker = &CircuitBreaker{FailureThreshold: 3, Cooldown: time.Hour}
+
+	// Failing to connect is retried even for push_tx, until the breaker opens.
+	if _, err := e.Call(FullNodePushTx, []byte(`{}`)); !isDialError(err) {
+		t.Errorf("Expected a dial error, got: %v", err)
+	}
+	if s := e.Breaker.State(); s != BreakerOpen {
+		t.Errorf("Breaker is %s after %d failures", s, testRetryPolicy.MaxAttempts)
+	}
+	if _, err := e.Call(WalletSyncStatus, []byte(`{}`)); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got: %v", err)
+	}
+}
+
+func TestCircuitBreakerRecovers(t *testing.T) {
+	b := &CircuitBreaker{FailureThreshold: 1, Cooldown: time.Millisecond}
+	if err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.Record(errors.New("connection refused"))
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Errorf("Trial call wasn't allowed: %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Second concurrent trial call was allowed")
+	}
+	// A service which answers, even unsuccessfully, is up.
+	b.Record(&APIError{Procedure: WalletGetBalance, Message: "Wallet id 9 does not exist"})
+	if s := b.State(); s != BreakerClosed {
+		t.Errorf("Breaker is %s after a successful trial", s)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	rp := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2, Jitter: 0.5}
+	for a, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		if d := rp.Backoff(a + 1); d < max/2 || d > max {
+			t.Errorf("Backoff after attempt %d is %s, expected between %s and %s", a+1, d, max/2, max)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package rpctest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Jsewill/chia/rpc"
+)
+
+// A CA is a throwaway private CA, issuing certificates the way Chia does.
+type CA struct {
+	Cert     *x509.Certificate
+	CertPath string // Path of the PEM encoded CA certificate.
+	key      *ecdsa.PrivateKey
+	serial   int64
+}
+
+// NewCA generates a new private CA, and writes its certificate to ca/private_ca.crt under dir, as Chia lays out its config/ssl directory.
+func NewCA(dir string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Chia CA", Organization: []string{"Chia"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't create CA certificate. Error: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	ca := &CA{Cert: cert, CertPath: filepath.Join(dir, "ca", "private_ca.crt"), key: key, serial: 1}
+	if err := writePEM(ca.CertPath, "CERTIFICATE", der); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// Pool returns a new *x509.CertPool holding the CA certificate.
+func (ca *CA) Pool() *x509.CertPool {
+	p := x509.NewCertPool()
+	p.AddCert(ca.Cert)
+	return p
+}
+
+// Issue returns a new certificate for chia.net, signed by the CA, for the named service, and writes it and its key to <service>/private_<service>.crt and .key under dir. It returns the certificate, and the paths of the certificate and key files.
+func (ca *CA) Issue(dir, service string) (tls.Certificate, string, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, "", "", err
+	}
+	ca.serial++
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: "Chia", Organization: []string{"Chia"}},
+		DNSNames:     []string{rpc.ChiaServerName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.Cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return tls.Certificate{}, "", "", fmt.Errorf("Couldn't create %s certificate. Error: %w", service, err)
+	}
+	kder, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, "", "", err
+	}
+	cp := filepath.Join(dir, service, "private_"+service+".crt")
+	kp := filepath.Join(dir, service, "private_"+service+".key")
+	if err := writePEM(cp, "CERTIFICATE", der); err != nil {
+		return tls.Certificate{}, "", "", err
+	}
+	if err := writePEM(kp, "EC PRIVATE KEY", kder); err != nil {
+		return tls.Certificate{}, "", "", err
+	}
+	c, err := tls.LoadX509KeyPair(cp, kp)
+	return c, cp, kp, err
+}
+
+// writePEM writes der to the file p, PEM encoded as typ, creating its directory if necessary.
+func writePEM(p, typ string, der []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(p, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600)
+}
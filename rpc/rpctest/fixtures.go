@@ -0,0 +1,284 @@
+package rpctest
+
+import "github.com/Jsewill/chia/rpc"
+
+// Fixture values, shared by the canned responses.
+const (
+	FixtureParentId   = "0x27ae41e4649b934ca495991b7852b85500000000000000000000000000000001"
+	FixtureCoinId     = "0xbbfd010fc101ce6c2a91caed5554b4fea606c60dd933e56f628b6a8b48267de6" // ID of the fixture coin.
+	FixturePuzzleHash = "0x4bc6435b409bcbabe53870dae0f03755f6aabb4594c5915ec983acf12a5d1fba"
+	FixtureHeaderHash = "0x0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9"
+	FixtureTxId       = "0x3e2d1c0b4a5968778695a4b3c2d1e0f93e2d1c0b4a5968778695a4b3c2d1e0f9"
+	FixtureDidHex     = "0x6a9f0e2d3c4b5a69788796a5b4c3d2e1f0a1b2c3d4e5f60718293a4b5c6d7e8f" // FixtureDidId, in hex.
+	FixtureAssetId    = "a628c1c2c6fcb74d53746157e438e108eab5c0bb3e5c80ff9b1910b3e4832913"
+	FixtureLauncherId = "2f0ef3b1c3ec6a9b3d4eee5f2a6b5c7d8e9f0a1b2c3d4e5f60718293a4b5c6d7"
+	FixtureTradeId    = "0x9a8b7c6d5e4f30211203f4e5d6c7b8a99a8b7c6d5e4f30211203f4e5d6c7b8a9"
+	FixtureOffer      = "offer1wfcxxar9wd6zqenf0p682un9yphkven9wgkzqcm0d4c8yetnwdjkggrnwpjkuepqvf6kuervv5s8xarpdejz66twee3psq"
+	FixtureNftId      = "nft19u808vwra34fk02wae0j566u0k8f7zsm9s75uhmqwxpf8f94cmtsj92av3" // FixtureLauncherId, encoded.
+	FixtureDidId      = "did:chia:1d20sutfufddxj7y8j6jmfs7ju8c2rvkr6njlvpcc9yaykhrd068sjunnt0"
+)
+
+const fixtureCoin = `{"amount": 1750000000000, "parent_coin_info": "` + FixtureParentId + `", "puzzle_hash": "` + FixturePuzzleHash + `"}`
+
+const fixtureCoinRecord = `{
+	"coin": ` + fixtureCoin + `,
+	"coinbase": true,
+	"confirmed_block_index": 1000,
+	"spent": false,
+	"spent_block_index": 0,
+	"timestamp": 1700000000
+}`
+
+const fixtureSpendBundle = `{
+	"aggregated_signature": "0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	"coin_solutions": []
+}`
+
+const fixtureBlockRecord = `{
+	"header_hash": "` + FixtureHeaderHash + `",
+	"prev_hash": "0x8d3d7a3b1dc1b8fc0ea5c3ed6bf7b1c6a0c1c85cf81e4e3e7d94e1f9a1b7f2c3",
+	"height": 1000,
+	"weight": 5000000000,
+	"total_iters": 4000000000000,
+	"signage_point_index": 12,
+	"challenge_vdf_output": {"data": "0x0300"},
+	"infused_challenge_vdf_output": null,
+	"reward_infusion_new_challenge": "0x1f7a",
+	"challenge_block_info_hash": "0x2b8c",
+	"sub_slot_iters": 147849216,
+	"pool_puzzle_hash": "` + FixturePuzzleHash + `",
+	"farmer_puzzle_hash": "` + FixturePuzzleHash + `",
+	"required_iters": 1234567,
+	"deficit": 0,
+	"overflow": false,
+	"prev_transaction_block_height": 998,
+	"timestamp": 1700000000,
+	"prev_transaction_block_hash": "0x6a1e",
+	"fees": 0,
+	"reward_claims_incorporated": [],
+	"finished_challenge_slot_hashes": null,
+	"finished_infused_challenge_slot_hashes": null,
+	"finished_reward_slot_hashes": null,
+	"sub_epoch_summary_included": null
+}`
+
+const fixtureFullBlock = `{
+	"finished_sub_slots": [],
+	"reward_chain_block": {
+		"weight": 5000000000,
+		"height": 1000,
+		"total_iters": 4000000000000,
+		"signage_point_index": 12,
+		"pos_ss_cc_challenge_hash": "0x3c4d",
+		"proof_of_space": {"challenge": "0x4d5e", "pool_public_key": null, "pool_contract_puzzle_hash": "` + FixturePuzzleHash + `", "plot_public_key": "0xa1", "size": 32, "proof": "0x00"},
+		"challenge_chain_sp_vdf": null,
+		"challenge_chain_sp_signature": "0xc0",
+		"challenge_chain_ip_vdf": {"challenge": "0x5e6f", "number_of_iterations": 1234567, "output": {"data": "0x0300"}},
+		"reward_chain_sp_vdf": null,
+		"reward_chain_sp_signature": "0xc0",
+		"reward_chain_ip_vdf": {"challenge": "0x6f70", "number_of_iterations": 1234567, "output": {"data": "0x0300"}},
+		"infused_challenge_chain_ip_vdf": null,
+		"is_transaction_block": true
+	},
+	"challenge_chain_sp_proof": null,
+	"challenge_chain_ip_proof": {"witness_type": 0, "witness": "0x01", "normalized_to_identity": false},
+	"reward_chain_sp_proof": null,
+	"reward_chain_ip_proof": {"witness_type": 0, "witness": "0x01", "normalized_to_identity": false},
+	"infused_challenge_chain_ip_proof": null,
+	"foliage": {
+		"prev_block_hash": "0x8d3d7a3b1dc1b8fc0ea5c3ed6bf7b1c6a0c1c85cf81e4e3e7d94e1f9a1b7f2c3",
+		"reward_block_hash": "0x7081",
+		"foliage_block_data": {"unfinished_reward_block_hash": "0x8192", "pool_target": {"puzzle_hash": "` + FixturePuzzleHash + `", "max_height": 0}, "pool_signature": null, "farmer_reward_puzzle_hash": "` + FixturePuzzleHash + `", "extension_data": "0x00"},
+		"foliage_block_data_signature": "0xc0",
+		"foliage_transaction_block_hash": "0x92a3",
+		"foliage_transaction_block_signature": "0xc0"
+	},
+	"foliage_transaction_block": {"prev_transaction_block_hash": "0x6a1e", "timestamp": 1700000000, "filter_hash": "0xa3b4", "additions_root": "0xb4c5", "removals_root": "0xc5d6", "transactions_info_hash": "0xd6e7"},
+	"transactions_info": {"generator_root": "0xe7f8", "generator_refs_root": "0xf809", "aggregated_signature": "0xc0", "fees": 0, "cost": 0, "reward_claims_incorporated": []},
+	"transactions_generator": null,
+	"transactions_generator_ref_list": []
+}`
+
+const fixtureMempoolItem = `{
+	"spend_bundle": {
+		"aggregated_signature": "0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"coin_spends": [{"coin": ` + fixtureCoin + `, "puzzle_reveal": "0x01", "solution": "0x80"}]
+	},
+	"fee": 100000,
+	"npc_result": {"error": null, "cost": 10000000, "conds": null},
+	"cost": 10000000,
+	"spend_bundle_name": "` + FixtureTxId + `",
+	"additions": [],
+	"removals": [],
+	"height_added_to_mempool": 1000
+}`
+
+const fixtureTransactionRecord = `{
+	"name": "` + FixtureTxId + `",
+	"type": 1,
+	"wallet_id": 1,
+	"amount": 1000000000,
+	"fee_amount": 100000,
+	"to_puzzle_hash": "` + FixturePuzzleHash + `",
+	"to_address": "xch1f0ryxk6qn096hefcwrdwpuph2hm24w69jnzezhkfswk0z2jar7aq5zzpfj",
+	"confirmed": true,
+	"confirmed_at_height": 1000,
+	"created_at_time": 1700000000,
+	"sent": 1,
+	"sent_to": [["0x5ab0", 1, null]],
+	"spend_bundle": ` + fixtureSpendBundle + `,
+	"additions": [` + fixtureCoin + `],
+	"removals": [],
+	"trade_id": null,
+	"memos": {"` + FixtureCoinId + `": "7061796f7574"},
+	"valid_times": {}
+}`
+
+const fixtureOfferSummary = `{
+	"offered": {"` + FixtureLauncherId + `": 1},
+	"requested": {"xch": 1000000000000},
+	"fees": 100000,
+	"infos": {"` + FixtureLauncherId + `": {
+		"type": "singleton",
+		"launcher_id": "0x` + FixtureLauncherId + `",
+		"launcher_ph": "0xeff07522495060c066f66f32acc2a77e3a3e737aca8baea4d1a64ea4cdc13da9",
+		"also": {
+			"type": "metadata",
+			"metadata": "((117 \"https://example.com/nft.png\"))",
+			"updater_hash": "0xfe8a4b4e27a2e29a4d3fc7ce9d527adbcaccbab6ada3903ccf3ba9a769d2d78b",
+			"also": {
+				"type": "ownership",
+				"owner": "()",
+				"transfer_program": {
+					"type": "royalty transfer program",
+					"launcher_id": "0x` + FixtureLauncherId + `",
+					"royalty_address": "` + FixturePuzzleHash + `",
+					"royalty_percentage": "300"
+				}
+			}
+		}
+	}}
+}`
+
+const fixtureTradeRecord = `{
+	"trade_id": "` + FixtureTradeId + `",
+	"status": "PENDING_ACCEPT",
+	"is_my_offer": true,
+	"created_at_time": 1700000000,
+	"accepted_at_time": null,
+	"confirmed_at_index": 0,
+	"sent": 0,
+	"sent_to": [],
+	"coins_of_interest": [` + fixtureCoin + `],
+	"summary": ` + fixtureOfferSummary + `,
+	"pending": {"` + FixtureLauncherId + `": 1},
+	"valid_times": {}
+}`
+
+const fixtureNetworkInfo = `{
+	"success": true,
+	"network_name": "mainnet",
+	"network_prefix": "xch",
+	"genesis_challenge": "0xccd5bb71183532bff220ba46c268991a3ff07eb358e8255a65c30a2dce0e5fbb"
+}`
+
+// Fixtures are the canned responses of a Server, by service name and procedure, for every procedure package rpc supports. They describe a synced mainnet node and wallet. Tests may change them before starting Servers, or override them per Server with its Handle methods.
+// Every Server also answers healthz, and get_routes with the procedures it handles.
+var Fixtures = map[string]map[rpc.Procedure]string{
+	rpc.ServiceFullNode: {
+		rpc.GetNetworkInfo:                    fixtureNetworkInfo,
+		rpc.FullNodeCoinRecordByName:          `{"success": true, "coin_record": ` + fixtureCoinRecord + `}`,
+		rpc.FullNodeCoinRecordByNames:         `{"success": true, "coin_records": [` + fixtureCoinRecord + `]}`,
+		rpc.FullNodeCoinRecordByParentIds:     `{"success": true, "coin_records": [` + fixtureCoinRecord + `]}`,
+		rpc.FullNodeCoinRecordByHints:         `{"success": true, "coin_records": [` + fixtureCoinRecord + `]}`,
+		rpc.FullNodeCoinRecordsByPuzzleHash:   `{"success": true, "coin_records": [` + fixtureCoinRecord + `]}`,
+		rpc.FullNodeCoinRecordsByPuzzleHashes: `{"success": true, "coin_records": [` + fixtureCoinRecord + `]}`,
+		rpc.FullNodePushTx:                    `{"success": true, "status": "SUCCESS"}`,
+		rpc.FullNodeGetBlockchainState: `{"success": true, "blockchain_state": {
+			"peak": ` + fixtureBlockRecord + `,
+			"genesis_challenge_initialized": true,
+			"sync": {"sync_mode": false, "synced": true, "sync_tip_height": 0, "sync_progress_height": 0},
+			"difficulty": 2944,
+			"sub_slot_iters": 147849216,
+			"space": 32000000000000000000,
+			"average_block_time": 18,
+			"mempool_size": 1,
+			"mempool_cost": 10000000,
+			"mempool_fees": 100000,
+			"mempool_min_fees": {"cost_5000000": 0},
+			"mempool_max_total_cost": 550000000000,
+			"block_max_cost": 11000000000,
+			"node_id": "0x5ab0"
+		}}`,
+		rpc.FullNodeGetBlock:                   `{"success": true, "block": ` + fixtureFullBlock + `}`,
+		rpc.FullNodeGetBlocks:                  `{"success": true, "blocks": [` + fixtureFullBlock + `]}`,
+		rpc.FullNodeGetBlockCountMetrics:       `{"success": true, "metrics": {"compact_blocks": 900, "uncompact_blocks": 100, "hint_count": 50}}`,
+		rpc.FullNodeGetBlockRecordByHeight:     `{"success": true, "block_record": ` + fixtureBlockRecord + `}`,
+		rpc.FullNodeGetBlockRecord:             `{"success": true, "block_record": ` + fixtureBlockRecord + `}`,
+		rpc.FullNodeGetBlockRecords:            `{"success": true, "block_records": [` + fixtureBlockRecord + `]}`,
+		rpc.FullNodeGetBlockSpends:             `{"success": true, "block_spends": []}`,
+		rpc.FullNodeGetUnfinishedBlockHeaders:  `{"success": true, "headers": []}`,
+		rpc.FullNodeGetNetworkSpace:            `{"success": true, "space": 32000000000000000000}`,
+		rpc.FullNodeGetAdditionsAndRemovals:    `{"success": true, "additions": [` + fixtureCoinRecord + `], "removals": []}`,
+		rpc.FullNodeGetPuzzleAndSolution:       `{"success": true, "coin_solution": {"coin": ` + fixtureCoin + `, "puzzle_reveal": "0xff01ff8080", "solution": "0x80"}}`,
+		rpc.FullNodeGetRecentSignagePointOrEOS: `{"success": true, "signage_point": {}, "time_received": 1700000000.5, "reverted": false}`,
+		rpc.FullNodeGetAllMempoolTxIds:         `{"success": true, "tx_ids": ["` + FixtureTxId + `"]}`,
+		rpc.FullNodeGetAllMempoolItems:         `{"success": true, "mempool_items": {"` + FixtureTxId + `": ` + fixtureMempoolItem + `}}`,
+		rpc.FullNodeGetMempoolItemByTxId:       `{"success": true, "mempool_item": ` + fixtureMempoolItem + `}`,
+		rpc.FullNodeGetFeeEstimate: `{"success": true, "estimates": [250000], "target_times": [300], "current_fee_rate": 0.025,
+			"mempool_size": 10000000, "mempool_fees": 100000, "mempool_max_size": 110000000000, "num_spends": 1, "full_node_synced": true,
+			"peak_height": 1000, "last_peak_timestamp": 1700000000, "node_time_utc": 1700000010, "last_block_cost": 10000000,
+			"fees_last_block": 100000, "fee_rate_last_block": 0.01, "last_tx_block_height": 1000}`,
+	},
+	rpc.ServiceWallet: {
+		rpc.GetNetworkInfo:   fixtureNetworkInfo,
+		rpc.WalletSyncStatus: `{"success": true, "genesis_initialized": true, "synced": true, "syncing": false}`,
+		rpc.WalletGetBalance: `{"success": true, "wallet_balance": {
+			"confirmed_wallet_balance": 1000000000000,
+			"fingerprint": 1234567890,
+			"max_send_amount": 1000000000000,
+			"pending_change": 0,
+			"pending_coin_removal_count": 0,
+			"spendable_balance": 1000000000000,
+			"unconfirmed_wallet_balance": 1000000000000,
+			"unspent_coin_amount": 1,
+			"wallet_id": 1
+		}}`,
+		rpc.WalletNFTMint:         `{"success": true, "wallet_id": 3, "spend_bundle": ` + fixtureSpendBundle + `}`,
+		rpc.WalletNFTMintBulk:     `{"success": true, "nft_id_list": ["` + FixtureNftId + `"], "spend_bundle": ` + fixtureSpendBundle + `}`,
+		rpc.WalletNFTGetWalletDID: `{"success": true, "did_id": "` + FixtureDidId + `"}`,
+		rpc.WalletPushTx:          `{"success": true, "status": "SUCCESS"}`,
+		rpc.WalletSendTransaction: `{"success": true, "transaction": ` + fixtureTransactionRecord + `, "transaction_id": "` + FixtureTxId + `"}`,
+		rpc.WalletGetTransactions: `{"success": true, "transactions": [` + fixtureTransactionRecord + `], "wallet_id": 1}`,
+		rpc.WalletGetTransaction:  `{"success": true, "transaction": ` + fixtureTransactionRecord + `, "transaction_id": "` + FixtureTxId + `"}`,
+		rpc.WalletGetWallets: `{"success": true, "fingerprint": 1234567890, "wallets": [
+			{"id": 1, "name": "Chia Wallet", "type": 0, "data": ""},
+			{"id": 2, "name": "Spacebucks", "type": 6, "data": "` + FixtureAssetId + `00"},
+			{"id": 3, "name": "DID Wallet", "type": 8, "data": ""},
+			{"id": 4, "name": "NFT Wallet", "type": 10, "data": "{\"did_id\": \"` + FixtureDidHex + `\"}"},
+			{"id": 5, "name": "NFT Wallet", "type": 10, "data": "{\"did_id\": null}"}
+		]}`,
+		rpc.WalletLogIn:                  `{"success": true, "fingerprint": 1234567890}`,
+		rpc.WalletGetLoggedInFingerprint: `{"success": true, "fingerprint": 1234567890}`,
+		rpc.WalletGetPublicKeys:          `{"success": true, "public_key_fingerprints": [1234567890]}`,
+		rpc.WalletGetPrivateKey:          `{"success": true, "private_key": {"fingerprint": 1234567890, "sk": "0x6c1f4e1c5f3b7d2a", "pk": "0xa0", "farmer_pk": "0xa1", "pool_pk": "0xa2", "seed": "abandon ability able about"}}`,
+		rpc.WalletGenerateMnemonic:       `{"success": true, "mnemonic": ["abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "abandon", "art"]}`,
+		rpc.WalletAddKey:                 `{"success": true, "fingerprint": 1234567890}`,
+		rpc.WalletDeleteKey:              `{"success": true}`,
+		rpc.WalletCreateNewWallet:        `{"success": true, "type": 6, "asset_id": "` + FixtureAssetId + `", "wallet_id": 2}`,
+		rpc.WalletCATSetName:             `{"success": true, "wallet_id": 2}`,
+		rpc.WalletCATGetName:             `{"success": true, "wallet_id": 2, "name": "Spacebucks"}`,
+		rpc.WalletCATGetAssetId:          `{"success": true, "wallet_id": 2, "asset_id": "` + FixtureAssetId + `"}`,
+		rpc.WalletCATSpend:               `{"success": true, "transaction": ` + fixtureTransactionRecord + `, "transaction_id": "` + FixtureTxId + `"}`,
+		rpc.WalletGetCATList:             `{"success": true, "cat_list": [{"asset_id": "` + FixtureAssetId + `", "name": "Spacebucks", "symbol": "SBX"}]}`,
+		rpc.WalletCreateOfferForIds:      `{"success": true, "offer": "` + FixtureOffer + `", "trade_record": ` + fixtureTradeRecord + `}`,
+		rpc.WalletGetOfferSummary:        `{"success": true, "id": "` + FixtureTradeId + `", "summary": ` + fixtureOfferSummary + `}`,
+		rpc.WalletCheckOfferValidity:     `{"success": true, "id": "` + FixtureTradeId + `", "valid": true}`,
+		rpc.WalletTakeOffer:              `{"success": true, "trade_record": ` + fixtureTradeRecord + `}`,
+		rpc.WalletGetOffer:               `{"success": true, "trade_record": ` + fixtureTradeRecord + `, "offer": "` + FixtureOffer + `"}`,
+		rpc.WalletGetAllOffers:           `{"success": true, "trade_records": [` + fixtureTradeRecord + `], "offers": ["` + FixtureOffer + `"]}`,
+		rpc.WalletCancelOffer:            `{"success": true}`,
+		rpc.WalletCancelOffers:           `{"success": true}`,
+		rpc.WalletGetTransactionCount:    `{"success": true, "count": 1, "wallet_id": 1}`,
+	},
+}
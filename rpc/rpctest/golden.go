@@ -0,0 +1,342 @@
+package rpctest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/Jsewill/chia/rpc"
+)
+
+// Errors returned by a Replayer for calls which weren't recorded.
+var (
+	ErrUnexpectedCall = errors.New("unexpected call")
+	ErrMismatchedCall = errors.New("mismatched call")
+)
+
+// A Golden is a recorded call, as stored in a golden file.
+type Golden struct {
+	Procedure   rpc.Procedure   `json:"procedure"`
+	Request     json.RawMessage `json:"request,omitempty"`
+	Response    json.RawMessage `json:"response,omitempty"`     // The response body, if it is JSON.
+	RawResponse string          `json:"raw_response,omitempty"` // The response body, if it isn't JSON.
+	Status      int             `json:"status,omitempty"`       // The HTTP status code, if known.
+	Error       string          `json:"error,omitempty"`        // The error of a call which got no response.
+}
+
+// body returns the recorded response body.
+func (g *Golden) body() []byte {
+	if g.Response != nil {
+		return g.Response
+	}
+	if g.RawResponse != "" {
+		return []byte(g.RawResponse)
+	}
+	return nil
+}
+
+// A Redaction configures how recorded requests and responses are rewritten before they are saved, so that golden files hold no secrets or identifying details.
+// Replacements are deterministic, so a value is replaced the same way wherever it appears, and a Replayer with the same Redaction matches requests made with the real values.
+type Redaction struct {
+	Keys         []string // Lower case fragments of JSON object keys whose values are replaced by "[REDACTED]", at any depth, as with rpc.RedactedKeys.
+	Fingerprints bool     // Replace wallet key fingerprints, the numbers under keys containing "fingerprint", such as "public_key_fingerprints", with stand-in fingerprints.
+	Addresses    bool     // Replace xch and txch addresses with stand-in addresses of the same prefix.
+}
+
+// DefaultRedaction redacts secret keys, fingerprints and addresses.
+var DefaultRedaction = Redaction{Keys: rpc.RedactedKeys, Fingerprints: true, Addresses: true}
+
+// Apply returns a copy of the JSON document j, redacted. If j isn't JSON, it is returned as is.
+func (r Redaction) Apply(j []byte) []byte {
+	if len(j) == 0 {
+		return j
+	}
+	d := json.NewDecoder(bytes.NewReader(j))
+	// Keep numbers exact; mojo amounts don't survive a round trip through float64.
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return j
+	}
+	out, err := json.Marshal(r.apply("", v))
+	if err != nil {
+		return j
+	}
+	return out
+}
+
+func (r Redaction) apply(k string, v any) any {
+	if v != nil && r.isKey(k) {
+		return "[REDACTED]"
+	}
+	switch t := v.(type) {
+	case map[string]any:
+		for ek, e := range t {
+			t[ek] = r.apply(ek, e)
+		}
+	case []any:
+		// Elements share the array's key, so that lists of fingerprints are redacted too.
+		for i, e := range t {
+			t[i] = r.apply(k, e)
+		}
+	case string:
+		if r.Addresses {
+			return redactAddress(t)
+		}
+	case json.Number:
+		if r.Fingerprints && strings.Contains(strings.ToLower(k), "fingerprint") {
+			return json.Number(fmt.Sprint(standIn("fingerprint", t.String())))
+		}
+	}
+	return v
+}
+
+func (r Redaction) isKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	k = strings.ToLower(k)
+	for _, f := range r.Keys {
+		// A fragment such as "_sk" also matches the bare key "sk".
+		if strings.Contains(k, f) || k == strings.TrimLeft(f, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+// redactAddress returns a stand-in for s, if it is an xch or txch address, otherwise s.
+func redactAddress(s string) string {
+	p, _, err := rpc.DecodeAddress(s)
+	if err != nil || p != "xch" && p != "txch" {
+		return s
+	}
+	h := sha256.Sum256([]byte("address:" + s))
+	a, err := rpc.EncodeAddress(p, hex.EncodeToString(h[:]))
+	if err != nil {
+		return s
+	}
+	return a
+}
+
+// standIn returns a deterministic stand-in for a 32 bit value v of the given kind.
+func standIn(kind, v string) uint32 {
+	h := sha256.Sum256([]byte(kind + ":" + v))
+	return binary.BigEndian.Uint32(h[:4])
+}
+
+// A GoldenRecorder records calls, redacted, for saving to a golden file, from which a Replayer can serve them. Calls are recorded through its Middleware, or at the HTTP level through its RoundTripper. It is safe for concurrent use.
+type GoldenRecorder struct {
+	Path      string // Path of the golden file written by Save.
+	Redaction Redaction
+	mu        sync.Mutex
+	calls     []Golden
+}
+
+// NewGoldenRecorder returns a new *GoldenRecorder, saving to the golden file at path, and redacting calls with DefaultRedaction.
+func NewGoldenRecorder(path string) *GoldenRecorder {
+	return &GoldenRecorder{Path: path, Redaction: DefaultRedaction}
+}
+
+// record adds a call to the recording.
+func (g *GoldenRecorder) record(p rpc.Procedure, req, resp []byte, status int, err error) {
+	c := Golden{Procedure: p, Status: status}
+	if req = g.Redaction.Apply(req); json.Valid(req) {
+		c.Request = req
+	}
+	if resp != nil {
+		if resp = g.Redaction.Apply(resp); json.Valid(resp) {
+			c.Response = resp
+		} else {
+			c.RawResponse = string(resp)
+		}
+	} else if err != nil {
+		c.Error = err.Error()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.calls = append(g.calls, c)
+}
+
+// Middleware returns rpc.Middleware which records each call.
+func (g *GoldenRecorder) Middleware() rpc.Middleware {
+	return func(next rpc.Caller) rpc.Caller {
+		return rpc.CallerFunc(func(ctx context.Context, p rpc.Procedure, j []byte) ([]byte, error) {
+			b, err := next.CallContext(ctx, p, j)
+			var s int
+			if ae, ok := rpc.AsAPIError(err); ok {
+				s = ae.StatusCode
+			}
+			g.record(p, j, b, s, err)
+			return b, err
+		})
+	}
+}
+
+// RoundTripper returns an http.RoundTripper which records each request made through next, such as an Endpoint's Transport. The procedure is taken from the request path.
+func (g *GoldenRecorder) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		var req []byte
+		if r.Body != nil {
+			var err error
+			if req, err = io.ReadAll(r.Body); err != nil {
+				return nil, err
+			}
+			r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(req))
+		}
+		p := rpc.Procedure(strings.TrimPrefix(r.URL.Path, "/"))
+		resp, err := next.RoundTrip(r)
+		if err != nil {
+			g.record(p, req, nil, 0, err)
+			return nil, err
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			return resp, err
+		}
+		g.record(p, req, b, resp.StatusCode, nil)
+		return resp, nil
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// Calls returns the calls recorded so far, in the order they returned.
+func (g *GoldenRecorder) Calls() []Golden {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Golden(nil), g.calls...)
+}
+
+// Save writes the calls recorded so far to the golden file, creating its directory if necessary.
+func (g *GoldenRecorder) Save() error {
+	b, err := json.MarshalIndent(g.Calls(), "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(g.Path), 0o755); err != nil {
+		return fmt.Errorf("Couldn't create golden file directory. Error: %w", err)
+	}
+	if err := os.WriteFile(g.Path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("Couldn't write golden file. Error: %w", err)
+	}
+	return nil
+}
+
+// A Replayer is a Caller which answers calls from recorded Goldens, failing on calls which weren't recorded. Each recorded call is answered once.
+// Requests are redacted with the Replayer's Redaction, which should be that they were recorded with, and then matched to recorded calls to the same procedure with equal JSON requests. It is safe for concurrent use.
+type Replayer struct {
+	Redaction Redaction
+	Ordered   bool // If true, calls must be made in the order they were recorded.
+	mu        sync.Mutex
+	calls     []Golden
+	used      []bool
+}
+
+// NewReplayer returns a new *Replayer answering with calls, redacting requests with DefaultRedaction.
+func NewReplayer(calls []Golden) *Replayer {
+	return &Replayer{Redaction: DefaultRedaction, calls: calls, used: make([]bool, len(calls))}
+}
+
+// LoadGolden returns a new *Replayer answering with the calls in the golden file at path, as written by GoldenRecorder.Save.
+func LoadGolden(path string) (*Replayer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read golden file. Error: %w", err)
+	}
+	var calls []Golden
+	if err := json.Unmarshal(b, &calls); err != nil {
+		return nil, fmt.Errorf("Couldn't parse golden file %s. Error: %w", path, err)
+	}
+	return NewReplayer(calls), nil
+}
+
+// CallContext answers the call with the matching recorded call. Unsuccessful recorded calls fail just as they did when recorded. Implements rpc.Caller.
+// Calls which match no remaining recorded call fail with ErrUnexpectedCall, or ErrMismatchedCall if there is a recorded call to the same procedure with a different request.
+func (r *Replayer) CallContext(ctx context.Context, p rpc.Procedure, j []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	req := r.Redaction.Apply(j)
+	r.mu.Lock()
+	var g *Golden
+	var mismatch *Golden
+	for i := range r.calls {
+		if r.used[i] {
+			continue
+		}
+		c := &r.calls[i]
+		if c.Procedure == p {
+			if jsonEqual(c.Request, req) {
+				r.used[i] = true
+				g = c
+				break
+			}
+			if mismatch == nil {
+				mismatch = c
+			}
+		}
+		if r.Ordered {
+			break
+		}
+	}
+	r.mu.Unlock()
+	if g == nil {
+		if mismatch != nil {
+			return nil, fmt.Errorf("Replayed %s with request %s, but recorded %s: %w", p, req, mismatch.Request, ErrMismatchedCall)
+		}
+		return nil, fmt.Errorf("Replayed %s with request %s, which wasn't recorded: %w", p, req, ErrUnexpectedCall)
+	}
+	if g.Error != "" {
+		return nil, errors.New(g.Error)
+	}
+	b := g.body()
+	return b, rpc.CheckResponse(p, "", g.Status, b)
+}
+
+// Remaining returns the recorded calls which haven't been replayed.
+func (r *Replayer) Remaining() []Golden {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var gs []Golden
+	for i, c := range r.calls {
+		if !r.used[i] {
+			gs = append(gs, c)
+		}
+	}
+	return gs
+}
+
+// jsonEqual reports whether JSON documents a and b are equal, ignoring formatting and key order. Empty documents equal each other.
+func jsonEqual(a, b []byte) bool {
+	if len(bytes.TrimSpace(a)) == 0 || len(bytes.TrimSpace(b)) == 0 {
+		return len(bytes.TrimSpace(a)) == len(bytes.TrimSpace(b))
+	}
+	var av, bv any
+	da, db := json.NewDecoder(bytes.NewReader(a)), json.NewDecoder(bytes.NewReader(b))
+	da.UseNumber()
+	db.UseNumber()
+	if da.Decode(&av) != nil || db.Decode(&bv) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(av, bv)
+}
@@ -0,0 +1,95 @@
+package rpctest
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	addr, err := rpc.EncodeAddress("xch", strings.Repeat("12", 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(t, rpc.ServiceWallet)
+	s.HandleJSON("log_in", `{"success": true, "fingerprint": 1234567890}`)
+	s.Fail("get_private_key", "No key with that fingerprint")
+	g := NewGoldenRecorder(filepath.Join(t.TempDir(), "testdata", "wallet.json"))
+	c := rpc.Chain(s.Endpoint(), g.Middleware())
+	ctx := context.Background()
+	if _, err := c.CallContext(ctx, "log_in", []byte(`{"fingerprint": 1234567890}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := new(rpc.WalletBalanceRequest).SendContext(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CallContext(ctx, "get_private_key", []byte(`{"fingerprint": 1234567890, "address": "`+addr+`", "sk": "00ff"}`)); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if err := g.Save(); err != nil {
+		t.Fatalf("Save failed: %s", err)
+	}
+	for _, c := range g.Calls() {
+		for _, secret := range []string{"1234567890", addr, "00ff"} {
+			if strings.Contains(string(c.Request)+string(c.Response), secret) {
+				t.Errorf("Recorded %s unredacted in %+v", secret, c)
+			}
+		}
+	}
+
+	r, err := LoadGolden(g.Path)
+	if err != nil {
+		t.Fatalf("LoadGolden failed: %s", err)
+	}
+	// Requests are matched after redaction, regardless of order.
+	_, err = r.CallContext(ctx, "get_private_key", []byte(`{"sk": "00ff", "address": "`+addr+`", "fingerprint": 1234567890}`))
+	if ae, ok := rpc.AsAPIError(err); !ok || ae.Message != "No key with that fingerprint" {
+		t.Errorf("Expected the recorded *APIError, got %v", err)
+	}
+	if _, err := r.CallContext(ctx, "log_in", []byte(`{"fingerprint": 1}`)); !errors.Is(err, ErrMismatchedCall) {
+		t.Errorf("Expected ErrMismatchedCall, got %v", err)
+	}
+	if _, err := r.CallContext(ctx, "log_in", []byte(`{"fingerprint": 1234567890}`)); err != nil {
+		t.Errorf("Replay failed: %s", err)
+	}
+	b, err := new(rpc.WalletBalanceRequest).SendContext(ctx, r)
+	if err != nil || b.WalletBalance.ConfirmedWalletBalance != 1000000000000 {
+		t.Errorf("Unexpected replay %+v, %v", b, err)
+	}
+	if _, err := r.CallContext(ctx, "log_in", []byte(`{"fingerprint": 1234567890}`)); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("Expected ErrUnexpectedCall, got %v", err)
+	}
+	if len(r.Remaining()) != 0 {
+		t.Errorf("Calls not replayed: %v", r.Remaining())
+	}
+}
+
+func TestRecordRoundTripper(t *testing.T) {
+	s := NewServer(t, rpc.ServiceWallet)
+	e := s.Endpoint()
+	g := NewGoldenRecorder(filepath.Join(t.TempDir(), "wallet.json"))
+	e.Client.Transport = g.RoundTripper(e.Transport)
+	if _, err := new(rpc.SyncStatusRequest).SendContext(context.Background(), e); err != nil {
+		t.Fatal(err)
+	}
+	cs := g.Calls()
+	if len(cs) != 1 || cs[0].Procedure != rpc.WalletSyncStatus || cs[0].Status != 200 || !strings.Contains(string(cs[0].Response), `"synced":true`) {
+		t.Errorf("Unexpected recording %+v", cs)
+	}
+}
+
+func TestRedactionFingerprints(t *testing.T) {
+	j := string(DefaultRedaction.Apply([]byte(`{"public_key_fingerprints": [1234567890, 987654321], "fingerprint": 1234567890, "height": 42}`)))
+	for _, secret := range []string{"1234567890", "987654321"} {
+		if strings.Contains(j, secret) {
+			t.Errorf("Fingerprint %s unredacted in %s", secret, j)
+		}
+	}
+	if !strings.Contains(j, `"height":42`) {
+		t.Errorf("Redacted more than fingerprints in %s", j)
+	}
+}
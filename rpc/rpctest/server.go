@@ -0,0 +1,230 @@
+/* Package rpctest provides an in-process fake Chia RPC service, for testing code which uses package rpc without a live Chia install. */
+package rpctest
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+)
+
+// A Handler answers the calls to one procedure. It is given the JSON request body, and returns the response, which is marshaled as JSON, unless it is a []byte, string or json.RawMessage already.
+// A map[string]any response without "success" is sent with "success": true. If the Handler returns an error, the response is {"success": false, "error": <message>}, as Chia sends.
+type Handler func(req []byte) (any, error)
+
+// A Call is a call received by a Server.
+type Call struct {
+	Procedure rpc.Procedure
+	Request   []byte
+}
+
+// answer is a complete response to a call.
+type answer func(req []byte) (int, []byte)
+
+// A Server is a fake Chia RPC service, served over TLS with certificates from its own private CA, which requires client certificates just as Chia does.
+// It answers each procedure with its Handler, or its fixture if it has none, and procedures it doesn't know with 404 Not Found. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+	Service  string // Name of the service the Server fakes, such as rpc.ServiceWallet.
+	Dir      string // Directory holding the certificates, laid out as Chia's config/ssl directory.
+	CA       *CA
+	CertPath string // Path of the client certificate, issued by CA.
+	KeyPath  string // Path of the client key.
+	mu       sync.Mutex
+	handlers map[rpc.Procedure]answer
+	calls    []Call
+}
+
+// NewServer starts a new Server faking the named service, answering with the service's Fixtures until told otherwise. It is closed, and its certificates removed, when the test ends.
+func NewServer(t testing.TB, service string) *Server {
+	t.Helper()
+	s, err := newServer(t.TempDir(), service)
+	if err != nil {
+		t.Fatalf("Couldn't start fake %s service. Error: %s", service, err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newServer does the work of NewServer, keeping certificates in dir.
+func newServer(dir, service string) (*Server, error) {
+	ca, err := NewCA(dir)
+	if err != nil {
+		return nil, err
+	}
+	sc, _, _, err := ca.Issue(filepath.Join(dir, "server"), service)
+	if err != nil {
+		return nil, err
+	}
+	_, cp, kp, err := ca.Issue(dir, service)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{Service: service, Dir: dir, CA: ca, CertPath: cp, KeyPath: kp, handlers: make(map[rpc.Procedure]answer)}
+	s.HandleJSON(rpc.Healthz, `{"success": true}`)
+	s.Handle(rpc.GetRoutes, func([]byte) (any, error) {
+		return map[string]any{"routes": s.routes()}, nil
+	})
+	for p, f := range Fixtures[service] {
+		s.HandleJSON(p, f)
+	}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	// Refused handshakes are expected of some tests, and needn't be logged.
+	s.Server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	s.Server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{sc},
+		ClientCAs:    ca.Pool(),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	s.Server.StartTLS()
+	return s, nil
+}
+
+// Handle sets the Handler for calls to procedure p.
+func (s *Server) Handle(p rpc.Procedure, h Handler) {
+	s.handle(p, func(req []byte) (int, []byte) {
+		v, err := h(req)
+		if err != nil {
+			return http.StatusOK, mustMarshal(map[string]any{"success": false, "error": err.Error()})
+		}
+		switch v := v.(type) {
+		case []byte:
+			return http.StatusOK, v
+		case json.RawMessage:
+			return http.StatusOK, v
+		case string:
+			return http.StatusOK, []byte(v)
+		case map[string]any:
+			if _, ok := v["success"]; !ok {
+				v["success"] = true
+			}
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return http.StatusInternalServerError, []byte(err.Error())
+		}
+		return http.StatusOK, b
+	})
+}
+
+// HandleJSON sets calls to procedure p to be answered with the JSON body.
+func (s *Server) HandleJSON(p rpc.Procedure, body string) {
+	s.HandleStatus(p, http.StatusOK, body)
+}
+
+// HandleStatus sets calls to procedure p to be answered with the HTTP status code and body.
+func (s *Server) HandleStatus(p rpc.Procedure, code int, body string) {
+	s.handle(p, func([]byte) (int, []byte) {
+		return code, []byte(body)
+	})
+}
+
+// Fail sets calls to procedure p to be answered with "success": false, and the error message msg.
+func (s *Server) Fail(p rpc.Procedure, msg string) {
+	s.HandleJSON(p, string(mustMarshal(map[string]any{"success": false, "error": msg})))
+}
+
+// Remove removes the handling of procedure p, including its fixture, so that calls to it are answered with 404 Not Found.
+func (s *Server) Remove(p rpc.Procedure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.handlers, p)
+}
+
+func (s *Server) handle(p rpc.Procedure, a answer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[p] = a
+}
+
+// routes returns the paths of the procedures the Server handles, sorted.
+func (s *Server) routes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rs := make([]string, 0, len(s.handlers))
+	for p := range s.handlers {
+		rs = append(rs, "/"+string(p))
+	}
+	sort.Strings(rs)
+	return rs
+}
+
+// Calls returns the calls the Server has received so far, in the order they arrived.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// CallsTo returns the calls to procedure p the Server has received so far.
+func (s *Server) CallsTo(p rpc.Procedure) []Call {
+	var cs []Call
+	for _, c := range s.Calls() {
+		if c.Procedure == p {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// Endpoint returns a new, initialized *rpc.Endpoint for the Server, using its client certificate.
+func (s *Server) Endpoint() *rpc.Endpoint {
+	host, p, err := net.SplitHostPort(s.Listener.Addr().String())
+	if err != nil {
+		panic(err)
+	}
+	port, err := strconv.ParseUint(p, 10, 0)
+	if err != nil {
+		panic(err)
+	}
+	e := &rpc.Endpoint{Name: s.Service, Host: host, Port: uint(port), CACertPath: s.CA.CertPath, CertPath: s.CertPath, KeyPath: s.KeyPath}
+	if err := e.Init(); err != nil {
+		panic(fmt.Sprintf("Couldn't initialize endpoint for fake %s service. Error: %s", s.Service, err))
+	}
+	return e
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "405: Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p := rpc.Procedure(strings.TrimPrefix(r.URL.Path, "/"))
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Procedure: p, Request: b})
+	a, ok := s.handlers[p]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "404: Not Found", http.StatusNotFound)
+		return
+	}
+	code, body := a(b)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+func mustMarshal(v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,82 @@
+package rpctest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+)
+
+func TestServerFixtures(t *testing.T) {
+	s := NewServer(t, rpc.ServiceWallet)
+	e := s.Endpoint()
+	r, err := new(rpc.WalletBalanceRequest).SendContext(context.Background(), e)
+	if err != nil {
+		t.Fatalf("Balance request failed: %s", err)
+	}
+	if r.WalletBalance.ConfirmedWalletBalance != 1000000000000 {
+		t.Errorf("Unexpected balance %+v", r.WalletBalance)
+	}
+	if err := rpc.VerifyNetwork(context.Background(), e, rpc.Mainnet); err != nil {
+		t.Errorf("Fixture network not mainnet: %s", err)
+	}
+	if cs := s.CallsTo(rpc.WalletGetBalance); len(cs) != 1 || string(cs[0].Request) != `{"wallet_id":0}` {
+		t.Errorf("Unexpected calls %v", cs)
+	}
+}
+
+func TestFixtureIds(t *testing.T) {
+	for _, c := range []struct{ id, prefix, hex string }{
+		{FixtureNftId, "nft", "0x" + FixtureLauncherId},
+		{FixtureDidId, "did:chia:", FixtureDidHex},
+	} {
+		p, h, err := rpc.DecodeAddress(c.id)
+		if err != nil || p != c.prefix || h != c.hex {
+			t.Errorf("%s decoded to %q %q, %v", c.id, p, h, err)
+		}
+	}
+}
+
+func TestServerHandlers(t *testing.T) {
+	s := NewServer(t, rpc.ServiceWallet)
+	e := s.Endpoint()
+	s.Handle(rpc.WalletSyncStatus, func(req []byte) (any, error) {
+		return map[string]any{"synced": false, "syncing": true}, nil
+	})
+	r, err := new(rpc.SyncStatusRequest).SendContext(context.Background(), e)
+	if err != nil || !r.Success || !r.Syncing {
+		t.Errorf("Unexpected response %+v, %v", r, err)
+	}
+	s.Handle(rpc.WalletSyncStatus, func(req []byte) (any, error) {
+		return nil, errors.New("wallet is syncing")
+	})
+	if _, err := new(rpc.SyncStatusRequest).SendContext(context.Background(), e); !errors.Is(err, rpc.ErrWalletNotSynced) {
+		t.Errorf("Expected ErrWalletNotSynced, got %v", err)
+	}
+	s.HandleStatus(rpc.WalletSyncStatus, http.StatusInternalServerError, "boom")
+	_, err = new(rpc.SyncStatusRequest).SendContext(context.Background(), e)
+	if ae, ok := rpc.AsAPIError(err); !ok || ae.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected a 500 *APIError, got %v", err)
+	}
+	s.Remove(rpc.WalletSyncStatus)
+	_, err = new(rpc.SyncStatusRequest).SendContext(context.Background(), e)
+	if ae, ok := rpc.AsAPIError(err); !ok || ae.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a 404 *APIError, got %v", err)
+	}
+}
+
+func TestServerRequiresClientCert(t *testing.T) {
+	s := NewServer(t, rpc.ServiceFullNode)
+	other := NewServer(t, rpc.ServiceFullNode)
+	e := s.Endpoint()
+	// A client certificate from another CA is refused.
+	e2 := &rpc.Endpoint{Name: e.Name, Host: e.Host, Port: e.Port, CACertPath: s.CA.CertPath, CertPath: other.CertPath, KeyPath: other.KeyPath}
+	if _, err := e2.Call(rpc.GetNetworkInfo, []byte(`{}`)); err == nil {
+		t.Error("Call with a foreign client certificate succeeded")
+	}
+	if _, err := e.Call(rpc.GetNetworkInfo, []byte(`{}`)); err != nil {
+		t.Errorf("Call failed: %s", err)
+	}
+}
@@ -1,8 +1,8 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 )
 
 const (
@@ -12,19 +12,43 @@ const (
 	WalletSyncStatus      Procedure = "get_sync_status"
 	WalletGetBalance      Procedure = `get_wallet_balance`
 	WalletPushTx          Procedure = `push_tx`
+
+	WalletSendTransaction     Procedure = "send_transaction"
+	WalletGetTransactions     Procedure = "get_transactions"
+	WalletGetTransaction      Procedure = "get_transaction"
+	WalletGetTransactionCount Procedure = "get_transaction_count"
+	WalletGetWallets          Procedure = "get_wallets"
+
+	WalletLogIn                  Procedure = "log_in"
+	WalletGetLoggedInFingerprint Procedure = "get_logged_in_fingerprint"
+	WalletGetPublicKeys          Procedure = "get_public_keys"
+	WalletGetPrivateKey          Procedure = "get_private_key"
+	WalletGenerateMnemonic       Procedure = "generate_mnemonic"
+	WalletAddKey                 Procedure = "add_key"
+	WalletDeleteKey              Procedure = "delete_key"
+
+	WalletCreateNewWallet Procedure = "create_new_wallet"
+	WalletCATSetName      Procedure = "cat_set_name"
+	WalletCATGetName      Procedure = "cat_get_name"
+	WalletCATGetAssetId   Procedure = "cat_get_asset_id"
+	WalletCATSpend        Procedure = "cat_spend"
+	WalletGetCATList      Procedure = "get_cat_list"
+
+	WalletCreateOfferForIds  Procedure = "create_offer_for_ids"
+	WalletGetOfferSummary    Procedure = "get_offer_summary"
+	WalletCheckOfferValidity Procedure = "check_offer_validity"
+	WalletTakeOffer          Procedure = "take_offer"
+	WalletGetOffer           Procedure = "get_offer"
+	WalletGetAllOffers       Procedure = "get_all_offers"
+	WalletCancelOffer        Procedure = "cancel_offer"
+	WalletCancelOffers       Procedure = "cancel_offers"
 )
 
 var (
-	Wallet *Endpoint = &Endpoint{Name: "wallet", Host: defaultHost, Port: 9256}
+	// Wallet is a convenience wallet endpoint, configured and initialized on first use. See Endpoint.
+	Wallet *Endpoint = &Endpoint{Name: ServiceWallet}
 )
 
-func init() {
-	err := Wallet.Init()
-	if err != nil {
-		logErr.Panicln(err)
-	}
-}
-
 type MetadataListItem struct {
 	Uris          []string `json:"uris"`
 	MetaUris      []string `json:"meta_uris,omitempty"`
@@ -39,8 +63,7 @@ type MetadataListItem struct {
 type MintBulkResponse struct {
 	NftIdList   []string     `json:"nft_id_list"`
 	SpendBundle *SpendBundle `json:"spend_bundle"`
-	Success     bool         `json:"success"`
-	Error       string       `json:"error"`
+	Response
 }
 
 type MintBulkRequest struct {
@@ -66,42 +89,24 @@ func (m *MintBulkRequest) Procedure() Procedure {
 	return WalletNFTMintBulk
 }
 
+// Send sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (m MintBulkRequest) Send(e *Endpoint) (*MintBulkResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(m)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(m.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	mbr := new(MintBulkResponse)
-	err = json.Unmarshal(out, mbr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return mbr, nil
+	return m.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (m MintBulkRequest) SendContext(ctx context.Context, caller Caller) (*MintBulkResponse, error) {
+	return Do[*MintBulkRequest, MintBulkResponse](ctx, caller, &m)
 }
 
 func (m *MintBulkRequest) String() string {
-	j, err := json.Marshal(m)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, m.Procedure(), j)
+	return requestString(m)
 }
 
 type MintResponse struct {
 	Spend_bundle *SpendBundle
-	Success      bool   `json:"success"`
-	WalletId     uint   `json:"wallet_id"`
-	Error        string `json:"error"`
+	WalletId     uint `json:"wallet_id"`
+	Response
 }
 
 type MintRequest struct {
@@ -127,35 +132,18 @@ func (m *MintRequest) Procedure() Procedure {
 	return WalletNFTMint
 }
 
+// Send sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (m MintRequest) Send(e *Endpoint) (*MintResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(m)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(m.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	mr := new(MintResponse)
-	err = json.Unmarshal(out, mr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return mr, nil
+	return m.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (m MintRequest) SendContext(ctx context.Context, caller Caller) (*MintResponse, error) {
+	return Do[*MintRequest, MintResponse](ctx, caller, &m)
 }
 
 func (m *MintRequest) String() string {
-	j, err := json.Marshal(m)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, m.Procedure(), j)
+	return requestString(m)
 }
 
 type Solution struct {
@@ -169,12 +157,28 @@ type SpendBundle struct {
 	CoinSolutions       []*Solution `json:"coin_solutions"`
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface. Current versions of Chia report coin spends as "coin_spends", and older ones as "coin_solutions"; either is accepted.
+func (s *SpendBundle) UnmarshalJSON(b []byte) error {
+	var sb struct {
+		AggregatedSignature string      `json:"aggregated_signature"`
+		CoinSpends          []*Solution `json:"coin_spends"`
+		CoinSolutions       []*Solution `json:"coin_solutions"`
+	}
+	if err := json.Unmarshal(b, &sb); err != nil {
+		return err
+	}
+	s.AggregatedSignature, s.CoinSolutions = sb.AggregatedSignature, sb.CoinSpends
+	if s.CoinSolutions == nil {
+		s.CoinSolutions = sb.CoinSolutions
+	}
+	return nil
+}
+
 type SyncStatusResponse struct {
-	GenesisInitialized bool   `json:"genesis_initialized"`
-	Success            bool   `json:"success"`
-	Synced             bool   `json:"synced"`
-	Syncing            bool   `json:"syncing"`
-	Error              string `json:"error"`
+	GenesisInitialized bool `json:"genesis_initialized"`
+	Synced             bool `json:"synced"`
+	Syncing            bool `json:"syncing"`
+	Response
 }
 
 type SyncStatusRequest struct{}
@@ -183,35 +187,18 @@ func (s *SyncStatusRequest) Procedure() Procedure {
 	return WalletSyncStatus
 }
 
+// Send sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (s *SyncStatusRequest) Send(e *Endpoint) (*SyncStatusResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(s)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(s.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	sr := new(SyncStatusResponse)
-	err = json.Unmarshal(out, sr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return sr, nil
+	return s.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (s *SyncStatusRequest) SendContext(ctx context.Context, caller Caller) (*SyncStatusResponse, error) {
+	return Do[*SyncStatusRequest, SyncStatusResponse](ctx, caller, s)
 }
 
 func (s *SyncStatusRequest) String() string {
-	j, err := json.Marshal(s)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, s.Procedure(), j)
+	return requestString(s)
 }
 
 type WalletBalanceRequest struct {
@@ -222,35 +209,18 @@ func (w *WalletBalanceRequest) Procedure() Procedure {
 	return WalletGetBalance
 }
 
+// Send sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (w *WalletBalanceRequest) Send(e *Endpoint) (*WalletBalanceResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(w)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(w.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	wr := new(WalletBalanceResponse)
-	err = json.Unmarshal(out, wr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return wr, nil
+	return w.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (w *WalletBalanceRequest) SendContext(ctx context.Context, caller Caller) (*WalletBalanceResponse, error) {
+	return Do[*WalletBalanceRequest, WalletBalanceResponse](ctx, caller, w)
 }
 
 func (w *WalletBalanceRequest) String() string {
-	j, err := json.Marshal(w)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, w.Procedure(), j)
+	return requestString(w)
 }
 
 type WalletBalance struct {
@@ -267,8 +237,7 @@ type WalletBalance struct {
 
 type WalletBalanceResponse struct {
 	WalletBalance *WalletBalance `json:"wallet_balance"`
-	Success       bool           `json:"success"`
-	Error         string         `json:"error"`
+	Response
 }
 
 type NftWalletGetDidRequest struct {
@@ -279,39 +248,21 @@ func (n *NftWalletGetDidRequest) Procedure() Procedure {
 	return WalletNFTGetWalletDID
 }
 
+// Send sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
 func (n *NftWalletGetDidRequest) Send(e *Endpoint) (*NftWalletGetDidResponse, error) {
-	// Marshal request body as JSON
-	j, err := json.Marshal(n)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Make request
-	out, err := e.Call(n.Procedure(), j)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	// Handle response
-	nr := new(NftWalletGetDidResponse)
-	err = json.Unmarshal(out, nr)
-	if err != nil {
-		logErr.Println(err)
-		return nil, err
-	}
-	return nr, nil
+	return n.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (n *NftWalletGetDidRequest) SendContext(ctx context.Context, caller Caller) (*NftWalletGetDidResponse, error) {
+	return Do[*NftWalletGetDidRequest, NftWalletGetDidResponse](ctx, caller, n)
 }
 
 func (n *NftWalletGetDidRequest) String() string {
-	j, err := json.Marshal(n)
-	if err != nil {
-		logErr.Println(err)
-	}
-	return fmt.Sprintf(`%s %q`, n.Procedure(), j)
+	return requestString(n)
 }
 
 type NftWalletGetDidResponse struct {
-	DidId   int    `json:"did_id"`
-	Success bool   `json:"success"`
-	Error   string `json:"error"`
+	DidId string `json:"did_id"` // DID of the wallet, such as "did:chia:1...", or empty if it has none.
+	Response
 }
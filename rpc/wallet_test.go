@@ -1,27 +1,131 @@
-package rpc
+package rpc_test
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Jsewill/chia/rpc"
+	"github.com/Jsewill/chia/rpc/rpctest"
+)
 
 func TestSyncStatus(t *testing.T) {
-	r := &SyncStatusRequest{}
-	status, err := r.Send(Wallet)
+	w := rpctest.NewServer(t, rpc.ServiceWallet).Endpoint()
+	r := &rpc.SyncStatusRequest{}
+	status, err := r.Send(w)
 	if err != nil {
-		t.Errorf("Sync Status Request failed: %s", err)
+		t.Fatalf("Sync Status Request failed: %s", err)
 	}
-	if !status.Success {
+	if !status.Success || !status.Synced {
 		t.Errorf("Sync Status Request was unsuccessful: %s", status.Error)
 	}
-	t.Log(status, err)
 }
 
 func TestWalletBalance(t *testing.T) {
-	r := &WalletBalanceRequest{WalletId: 1}
-	balance, err := r.Send(Wallet)
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	r := &rpc.WalletBalanceRequest{WalletId: 1}
+	balance, err := r.Send(s.Endpoint())
 	if err != nil {
-		t.Errorf("Wallet Balance Request failed: %s", err)
+		t.Fatalf("Wallet Balance Request failed: %s", err)
 	}
-	if !balance.Success {
+	if !balance.Success || balance.WalletBalance.WalletId != 1 {
 		t.Errorf("Wallet Balance Request was unsuccessful: %s", balance.Error)
 	}
-	t.Log(balance, err)
+	if cs := s.CallsTo(rpc.WalletGetBalance); len(cs) != 1 || string(cs[0].Request) != `{"wallet_id":1}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+}
+
+func TestSendTransaction(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	w := s.Endpoint()
+	r, err := (&rpc.SendTransactionRequest{WalletId: 1, Amount: 1000000000, Address: "xch1f0ryxk6qn096hefcwrdwpuph2hm24w69jnzezhkfswk0z2jar7aq5zzpfj", Fee: 100000, Memos: []string{"payout"}}).SendContext(context.Background(), w)
+	if err != nil {
+		t.Fatalf("Send Transaction Request failed: %s", err)
+	}
+	tx := r.Transaction
+	if r.TransactionId != rpctest.FixtureTxId || tx.Type != rpc.OutgoingTx || !tx.Confirmed || len(tx.Additions) != 1 {
+		t.Errorf("Unexpected transaction: %+v", tx)
+	}
+	if len(tx.SentTo) != 1 || tx.SentTo[0].Status != rpc.MempoolSuccess || tx.SentTo[0].Error != "" || tx.InMempool() {
+		t.Errorf("Unexpected sent_to: %+v", tx.SentTo)
+	}
+	want := `{"wallet_id":1,"amount":1000000000,"address":"xch1f0ryxk6qn096hefcwrdwpuph2hm24w69jnzezhkfswk0z2jar7aq5zzpfj","fee":100000,"memos":["payout"]}`
+	if cs := s.CallsTo(rpc.WalletSendTransaction); len(cs) != 1 || string(cs[0].Request) != want {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	if !rpc.NonIdempotent[rpc.WalletSendTransaction] {
+		t.Error("Sending a transaction must not be retried")
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	w := s.Endpoint()
+	ctx := context.Background()
+	confirmed := true
+	r, err := (&rpc.TransactionsRequest{
+		WalletId:   1,
+		End:        50,
+		SortKey:    rpc.SortByRelevance,
+		TypeFilter: &rpc.TransactionTypeFilter{Values: []rpc.TransactionType{rpc.OutgoingTx}, Mode: rpc.FilterInclude},
+		Confirmed:  &confirmed,
+	}).SendContext(ctx, w)
+	if err != nil {
+		t.Fatalf("Transactions Request failed: %s", err)
+	}
+	if len(r.Transactions) != 1 || r.Transactions[0].ConfirmedAtHeight != 1000 {
+		t.Errorf("Unexpected transactions: %+v", r.Transactions)
+	}
+	want := `{"wallet_id":1,"start":0,"end":50,"sort_key":"RELEVANCE","type_filter":{"values":[1],"mode":1},"confirmed":true}`
+	if cs := s.CallsTo(rpc.WalletGetTransactions); len(cs) != 1 || string(cs[0].Request) != want {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	tr, err := (&rpc.TransactionRequest{TransactionId: rpctest.FixtureTxId}).SendContext(ctx, w)
+	if err != nil || tr.Transaction.Name != rpctest.FixtureTxId {
+		t.Errorf("Unexpected transaction: %+v, %v", tr, err)
+	}
+	if m := tr.Transaction.Memos[rpctest.FixtureCoinId]; len(m) != 1 || m[0] != "7061796f7574" {
+		t.Errorf("Unexpected memos: %v", tr.Transaction.Memos)
+	}
+	// A rejected transaction reports its peers' errors.
+	s.HandleJSON(rpc.WalletGetTransaction, `{"success": true, "transaction": {"confirmed": false, "sent_to": [["0x01", 3, "DOUBLE_SPEND"], ["0x02", 2, null]], "memos": {"0x01": ["61", "62"]}}}`)
+	tr, err = (&rpc.TransactionRequest{TransactionId: rpctest.FixtureTxId}).SendContext(ctx, w)
+	if err != nil || !tr.Transaction.InMempool() || len(tr.Transaction.Errors()) != 1 || tr.Transaction.Errors()[0] != "DOUBLE_SPEND" || len(tr.Transaction.Memos["0x01"]) != 2 {
+		t.Errorf("Unexpected transaction: %+v, %v", tr, err)
+	}
+}
+
+func TestFindWallets(t *testing.T) {
+	s := rpctest.NewServer(t, rpc.ServiceWallet)
+	w := s.Endpoint()
+	ctx := context.Background()
+	std, err := rpc.FindWallet(ctx, w, rpc.StandardWallet)
+	if err != nil || std.Id != 1 {
+		t.Errorf("Unexpected standard wallet: %+v, %v", std, err)
+	}
+	if cs := s.CallsTo(rpc.WalletGetWallets); len(cs) != 1 || string(cs[0].Request) != `{"type":0}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
+	cat, err := rpc.FindCATWallet(ctx, w, "0x"+strings.ToUpper(rpctest.FixtureAssetId))
+	if err != nil || cat.Id != 2 {
+		t.Errorf("Unexpected CAT wallet: %+v, %v", cat, err)
+	}
+	nft, err := rpc.FindNFTWalletForDID(ctx, w, rpctest.FixtureDidId)
+	if err != nil || nft.Id != 4 {
+		t.Errorf("Unexpected NFT wallet: %+v, %v", nft, err)
+	}
+	if _, err := rpc.FindWallet(ctx, w, rpc.PoolWallet); !errors.Is(err, rpc.ErrUnknownWallet) {
+		t.Errorf("Expected ErrUnknownWallet, got %v", err)
+	}
+	// Without wallet data, the NFT wallets are asked for their DIDs.
+	s.HandleJSON(rpc.WalletGetWallets, `{"success": true, "wallets": [{"id": 7, "name": "NFT Wallet", "type": 10, "data": ""}]}`)
+	nft, err = rpc.FindNFTWalletForDID(ctx, w, rpctest.FixtureDidHex)
+	if err != nil || nft.Id != 7 {
+		t.Errorf("Unexpected NFT wallet: %+v, %v", nft, err)
+	}
+	if cs := s.CallsTo(rpc.WalletNFTGetWalletDID); len(cs) != 1 || string(cs[0].Request) != `{"wallet_id":7}` {
+		t.Errorf("Unexpected calls: %v", cs)
+	}
 }
@@ -0,0 +1,368 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionType is the type of a TransactionRecord.
+type TransactionType uint
+
+const (
+	IncomingTx              TransactionType = iota // XCH or tokens received.
+	OutgoingTx                                     // XCH or tokens sent.
+	CoinbaseReward                                 // Pool farming reward.
+	FeeReward                                      // Farmer reward, including fees.
+	IncomingTrade                                  // Received in an accepted offer.
+	OutgoingTrade                                  // Sent in an accepted offer.
+	IncomingClawbackReceive                        // Received with a clawback, not yet claimable.
+	IncomingClawbackSend                           // Sent with a clawback, from the sender's view.
+	OutgoingClawback                               // Clawed back, or claimed.
+	IncomingCRCATPending                           // Received credential restricted CAT, awaiting approval.
+)
+
+// String implements the fmt.Stringer interface.
+func (t TransactionType) String() string {
+	switch t {
+	case IncomingTx:
+		return "incoming"
+	case OutgoingTx:
+		return "outgoing"
+	case CoinbaseReward:
+		return "coinbase reward"
+	case FeeReward:
+		return "fee reward"
+	case IncomingTrade:
+		return "incoming trade"
+	case OutgoingTrade:
+		return "outgoing trade"
+	case IncomingClawbackReceive:
+		return "incoming clawback receive"
+	case IncomingClawbackSend:
+		return "incoming clawback send"
+	case OutgoingClawback:
+		return "outgoing clawback"
+	case IncomingCRCATPending:
+		return "incoming CR-CAT pending"
+	}
+	return fmt.Sprintf("TransactionType(%d)", uint(t))
+}
+
+// MempoolInclusionStatus is a peer's answer to a transaction sent to it.
+type MempoolInclusionStatus int
+
+const (
+	MempoolSuccess MempoolInclusionStatus = 1 // Added to the mempool.
+	MempoolPending MempoolInclusionStatus = 2 // Not added yet, but may be later.
+	MempoolFailed  MempoolInclusionStatus = 3 // Rejected.
+)
+
+// String implements the fmt.Stringer interface.
+func (s MempoolInclusionStatus) String() string {
+	switch s {
+	case MempoolSuccess:
+		return "SUCCESS"
+	case MempoolPending:
+		return "PENDING"
+	case MempoolFailed:
+		return "FAILED"
+	}
+	return fmt.Sprintf("MempoolInclusionStatus(%d)", int(s))
+}
+
+// SentTo records a peer a transaction was sent to, and its answer. Chia encodes it as a [peer, status, error] array.
+type SentTo struct {
+	Peer   string
+	Status MempoolInclusionStatus
+	Error  string // The peer's error, such as "DOUBLE_SPEND", if any.
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SentTo) UnmarshalJSON(b []byte) error {
+	var a []json.RawMessage
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	if len(a) != 3 {
+		return fmt.Errorf("Invalid sent_to entry %s; expected 3 elements.", b)
+	}
+	var e *string
+	if err := json.Unmarshal(a[0], &s.Peer); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(a[1], &s.Status); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(a[2], &e); err != nil {
+		return err
+	}
+	s.Error = ""
+	if e != nil {
+		s.Error = *e
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s SentTo) MarshalJSON() ([]byte, error) {
+	var e *string
+	if s.Error != "" {
+		e = &s.Error
+	}
+	return json.Marshal([]any{s.Peer, s.Status, e})
+}
+
+// ConditionValidTimes are the time and height limits, set by conditions, within which a transaction is valid.
+type ConditionValidTimes struct {
+	MinSecsSinceCreated   *uint `json:"min_secs_since_created,omitempty"`
+	MinTime               *uint `json:"min_time,omitempty"`
+	MinBlocksSinceCreated *uint `json:"min_blocks_since_created,omitempty"`
+	MinHeight             *uint `json:"min_height,omitempty"`
+	MaxSecsAfterCreated   *uint `json:"max_secs_after_created,omitempty"`
+	MaxTime               *uint `json:"max_time,omitempty"`
+	MaxBlocksAfterCreated *uint `json:"max_blocks_after_created,omitempty"`
+	MaxHeight             *uint `json:"max_height,omitempty"`
+}
+
+// TransactionMemos are the memos of a transaction in hex, by coin ID. Chia reports a single memo per coin, as a string, but lists are accepted too.
+type TransactionMemos map[string][]string
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *TransactionMemos) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*m = nil
+		return nil
+	}
+	*m = make(TransactionMemos, len(raw))
+	for id, v := range raw {
+		var one string
+		if err := json.Unmarshal(v, &one); err == nil {
+			(*m)[id] = []string{one}
+			continue
+		}
+		var many []string
+		if err := json.Unmarshal(v, &many); err != nil {
+			return fmt.Errorf("Invalid memos of coin %s. Error: %w", id, err)
+		}
+		(*m)[id] = many
+	}
+	return nil
+}
+
+// TransactionRecord is a wallet's record of a transaction. Amounts are in mojos, or the smallest unit of the wallet's token.
+type TransactionRecord struct {
+	Name              string               `json:"name"` // Transaction ID.
+	Type              TransactionType      `json:"type"`
+	WalletId          uint                 `json:"wallet_id"`
+	Amount            uint                 `json:"amount"`
+	FeeAmount         uint                 `json:"fee_amount"`
+	ToPuzzleHash      string               `json:"to_puzzle_hash"`
+	ToAddress         string               `json:"to_address,omitempty"`
+	Confirmed         bool                 `json:"confirmed"`
+	ConfirmedAtHeight uint                 `json:"confirmed_at_height"`
+	CreatedAtTime     uint                 `json:"created_at_time"` // Unix time.
+	Sent              uint                 `json:"sent"`            // Number of times sent to peers.
+	SentTo            []SentTo             `json:"sent_to"`
+	SpendBundle       *SpendBundle         `json:"spend_bundle"` // Nil for incoming transactions.
+	Additions         []*Coin              `json:"additions"`
+	Removals          []*Coin              `json:"removals"`
+	TradeId           string               `json:"trade_id,omitempty"`
+	Memos             TransactionMemos     `json:"memos"`
+	ValidTimes        *ConditionValidTimes `json:"valid_times,omitempty"`
+}
+
+// InMempool reports whether an unconfirmed transaction was accepted, or held pending, by any peer it was sent to.
+func (t *TransactionRecord) InMempool() bool {
+	if t.Confirmed {
+		return false
+	}
+	for _, s := range t.SentTo {
+		if s.Status == MempoolSuccess || s.Status == MempoolPending {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the errors of the peers which rejected the transaction.
+func (t *TransactionRecord) Errors() []string {
+	var es []string
+	for _, s := range t.SentTo {
+		if s.Status == MempoolFailed && s.Error != "" {
+			es = append(es, s.Error)
+		}
+	}
+	return es
+}
+
+// SendTransactionResponse represents the Chia RPC API's response to a SendTransactionRequest.
+type SendTransactionResponse struct {
+	Transaction   *TransactionRecord   `json:"transaction"`
+	TransactionId string               `json:"transaction_id"`
+	Transactions  []*TransactionRecord `json:"transactions,omitempty"` // Reported by recent versions of Chia only; the transaction, and any others made with it, such as for fees.
+	Response
+}
+
+// SendTransactionRequest is a type for making a request to send XCH, or a wallet's token, to an address. Amount and Fee are in mojos.
+type SendTransactionRequest struct {
+	WalletId           uint     `json:"wallet_id"`
+	Amount             uint     `json:"amount"`
+	Address            string   `json:"address"`
+	Fee                uint     `json:"fee"`
+	Memos              []string `json:"memos,omitempty"`
+	MinCoinAmount      uint     `json:"min_coin_amount,omitempty"`
+	MaxCoinAmount      uint     `json:"max_coin_amount,omitempty"`
+	ExcludeCoinAmounts []uint   `json:"exclude_coin_amounts,omitempty"`
+	ExcludeCoinIds     []string `json:"exclude_coin_ids,omitempty"`
+	ReusePuzHash       bool     `json:"reuse_puzhash,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (s *SendTransactionRequest) Procedure() Procedure {
+	return WalletSendTransaction
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (s *SendTransactionRequest) Send(e *Endpoint) (*SendTransactionResponse, error) {
+	return s.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (s *SendTransactionRequest) SendContext(ctx context.Context, caller Caller) (*SendTransactionResponse, error) {
+	return Do[*SendTransactionRequest, SendTransactionResponse](ctx, caller, s)
+}
+
+// String implements the fmt.Stringer interface.
+func (s *SendTransactionRequest) String() string {
+	return requestString(s)
+}
+
+// Sort keys of a GetTransactionsRequest.
+const (
+	SortByConfirmedAtHeight = "CONFIRMED_AT_HEIGHT"
+	SortByRelevance         = "RELEVANCE" // Unconfirmed first, then by height, newest first.
+)
+
+// FilterMode tells whether a TransactionTypeFilter includes or excludes its types.
+type FilterMode int
+
+const (
+	FilterInclude FilterMode = 1
+	FilterExclude FilterMode = 2
+)
+
+// TransactionTypeFilter selects transactions by type.
+type TransactionTypeFilter struct {
+	Values []TransactionType `json:"values"`
+	Mode   FilterMode        `json:"mode"`
+}
+
+// TransactionsResponse represents the Chia RPC API's response to a TransactionsRequest.
+type TransactionsResponse struct {
+	Transactions []*TransactionRecord `json:"transactions"`
+	WalletId     uint                 `json:"wallet_id"`
+	Response
+}
+
+// TransactionsRequest is a type for making a request for a page of a wallet's transactions, from index Start to End, exclusive, in the order given by SortKey and Reverse.
+type TransactionsRequest struct {
+	WalletId   uint                   `json:"wallet_id"`
+	Start      uint                   `json:"start"`
+	End        uint                   `json:"end,omitempty"` // If zero, the node's default page size is used.
+	SortKey    string                 `json:"sort_key,omitempty"`
+	Reverse    bool                   `json:"reverse,omitempty"`
+	ToAddress  string                 `json:"to_address,omitempty"`
+	TypeFilter *TransactionTypeFilter `json:"type_filter,omitempty"`
+	Confirmed  *bool                  `json:"confirmed,omitempty"` // If set, only confirmed, or unconfirmed, transactions.
+}
+
+// Procedure returns the Procedure which this request will use.
+func (t *TransactionsRequest) Procedure() Procedure {
+	return WalletGetTransactions
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (t *TransactionsRequest) Send(e *Endpoint) (*TransactionsResponse, error) {
+	return t.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (t *TransactionsRequest) SendContext(ctx context.Context, caller Caller) (*TransactionsResponse, error) {
+	return Do[*TransactionsRequest, TransactionsResponse](ctx, caller, t)
+}
+
+// String implements the fmt.Stringer interface.
+func (t *TransactionsRequest) String() string {
+	return requestString(t)
+}
+
+// TransactionResponse represents the Chia RPC API's response to a TransactionRequest.
+type TransactionResponse struct {
+	Transaction   *TransactionRecord `json:"transaction"`
+	TransactionId string             `json:"transaction_id"`
+	Response
+}
+
+// TransactionRequest is a type for making a request for a transaction by ID.
+type TransactionRequest struct {
+	TransactionId string `json:"transaction_id"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (t *TransactionRequest) Procedure() Procedure {
+	return WalletGetTransaction
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (t *TransactionRequest) Send(e *Endpoint) (*TransactionResponse, error) {
+	return t.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (t *TransactionRequest) SendContext(ctx context.Context, caller Caller) (*TransactionResponse, error) {
+	return Do[*TransactionRequest, TransactionResponse](ctx, caller, t)
+}
+
+// String implements the fmt.Stringer interface.
+func (t *TransactionRequest) String() string {
+	return requestString(t)
+}
+
+// TransactionCountResponse represents the Chia RPC API's response to a TransactionCountRequest.
+type TransactionCountResponse struct {
+	Count    uint `json:"count"`
+	WalletId uint `json:"wallet_id"`
+	Response
+}
+
+// TransactionCountRequest is a type for making a request for the number of a wallet's transactions, for paging with TransactionsRequest.
+type TransactionCountRequest struct {
+	WalletId   uint                   `json:"wallet_id"`
+	TypeFilter *TransactionTypeFilter `json:"type_filter,omitempty"`
+	Confirmed  *bool                  `json:"confirmed,omitempty"`
+}
+
+// Procedure returns the Procedure which this request will use.
+func (t *TransactionCountRequest) Procedure() Procedure {
+	return WalletGetTransactionCount
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (t *TransactionCountRequest) Send(e *Endpoint) (*TransactionCountResponse, error) {
+	return t.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (t *TransactionCountRequest) SendContext(ctx context.Context, caller Caller) (*TransactionCountResponse, error) {
+	return Do[*TransactionCountRequest, TransactionCountResponse](ctx, caller, t)
+}
+
+// String implements the fmt.Stringer interface.
+func (t *TransactionCountRequest) String() string {
+	return requestString(t)
+}
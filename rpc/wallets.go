@@ -0,0 +1,227 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// WalletType is the type of a wallet, as Chia numbers them.
+type WalletType int
+
+const (
+	StandardWallet        WalletType = 0
+	AtomicSwapWallet      WalletType = 2
+	AuthorizedPayeeWallet WalletType = 3
+	MultiSigWallet        WalletType = 4
+	CustodyWallet         WalletType = 5
+	CATWallet             WalletType = 6
+	RecoverableWallet     WalletType = 7
+	DIDWallet             WalletType = 8
+	PoolWallet            WalletType = 9
+	NFTWallet             WalletType = 10
+	DataLayerWallet       WalletType = 11
+	DataLayerOfferWallet  WalletType = 12
+	VCWallet              WalletType = 13
+	CRCATWallet           WalletType = 57
+)
+
+// String implements the fmt.Stringer interface.
+func (t WalletType) String() string {
+	switch t {
+	case StandardWallet:
+		return "standard"
+	case AtomicSwapWallet:
+		return "atomic swap"
+	case AuthorizedPayeeWallet:
+		return "authorized payee"
+	case MultiSigWallet:
+		return "multi sig"
+	case CustodyWallet:
+		return "custody"
+	case CATWallet:
+		return "CAT"
+	case RecoverableWallet:
+		return "recoverable"
+	case DIDWallet:
+		return "DID"
+	case PoolWallet:
+		return "pool"
+	case NFTWallet:
+		return "NFT"
+	case DataLayerWallet:
+		return "DataLayer"
+	case DataLayerOfferWallet:
+		return "DataLayer offer"
+	case VCWallet:
+		return "VC"
+	case CRCATWallet:
+		return "CR-CAT"
+	}
+	return fmt.Sprintf("WalletType(%d)", int(t))
+}
+
+// WalletInfo describes a wallet of the wallet service's logged in key.
+type WalletInfo struct {
+	Id   uint       `json:"id"`
+	Name string     `json:"name"`
+	Type WalletType `json:"type"`
+	Data string     `json:"data"` // Type specific data; for CAT wallets, the asset ID and more, in hex, and for NFT wallets, JSON with the wallet's DID.
+}
+
+// AssetId returns the asset ID of a CAT wallet, in hex without a 0x prefix, and whether it has one.
+func (w *WalletInfo) AssetId() (string, bool) {
+	if w.Type != CATWallet && w.Type != CRCATWallet {
+		return "", false
+	}
+	// The data begins with the asset ID, the hash of the CAT's TAIL.
+	d := strings.TrimPrefix(w.Data, "0x")
+	if len(d) < 64 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(d[:64]); err != nil {
+		return "", false
+	}
+	return strings.ToLower(d[:64]), true
+}
+
+// DidId returns the DID of an NFT wallet, in hex with a 0x prefix, and whether it has one.
+func (w *WalletInfo) DidId() (string, bool) {
+	if w.Type != NFTWallet || w.Data == "" {
+		return "", false
+	}
+	var d struct {
+		DidId *string `json:"did_id"`
+	}
+	if err := json.Unmarshal([]byte(w.Data), &d); err != nil || d.DidId == nil || *d.DidId == "" {
+		return "", false
+	}
+	return normalizeHex(*d.DidId), true
+}
+
+// WalletsResponse represents the Chia RPC API's response to a WalletsRequest.
+type WalletsResponse struct {
+	Wallets     []*WalletInfo `json:"wallets"`
+	Fingerprint uint          `json:"fingerprint"`
+	Response
+}
+
+// WalletsRequest is a type for making a request for the wallets of the logged in key, optionally of a single type.
+type WalletsRequest struct {
+	Type        *WalletType `json:"type,omitempty"`
+	IncludeData *bool       `json:"include_data,omitempty"` // If nil, Chia includes the data.
+}
+
+// Procedure returns the Procedure which this request will use.
+func (w *WalletsRequest) Procedure() Procedure {
+	return WalletGetWallets
+}
+
+// Sends the request via an Endpoint, and returns the response, and an error. If successful, error returns nil.
+func (w *WalletsRequest) Send(e *Endpoint) (*WalletsResponse, error) {
+	return w.SendContext(context.Background(), e)
+}
+
+// SendContext sends the request via a Caller, with a Context, and returns the response, and an error. If successful, error returns nil.
+func (w *WalletsRequest) SendContext(ctx context.Context, caller Caller) (*WalletsResponse, error) {
+	return Do[*WalletsRequest, WalletsResponse](ctx, caller, w)
+}
+
+// String implements the fmt.Stringer interface.
+func (w *WalletsRequest) String() string {
+	return requestString(w)
+}
+
+// FindWallets returns the wallets of type t of the wallet service behind c.
+func FindWallets(ctx context.Context, c Caller, t WalletType) ([]*WalletInfo, error) {
+	r, err := (&WalletsRequest{Type: &t}).SendContext(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't get %s wallets. Error: %w", t, err)
+	}
+	// Older versions of Chia ignore the type.
+	ws := make([]*WalletInfo, 0, len(r.Wallets))
+	for _, w := range r.Wallets {
+		if w.Type == t {
+			ws = append(ws, w)
+		}
+	}
+	return ws, nil
+}
+
+// FindWallet returns the first wallet of type t, such as StandardWallet, of the wallet service behind c. If there is none, the error wraps ErrUnknownWallet.
+func FindWallet(ctx context.Context, c Caller, t WalletType) (*WalletInfo, error) {
+	ws, err := FindWallets(ctx, c, t)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws) == 0 {
+		return nil, fmt.Errorf("No %s wallet: %w", t, ErrUnknownWallet)
+	}
+	return ws[0], nil
+}
+
+// FindCATWallet returns the CAT wallet of the asset with the given ID, in hex, with or without a 0x prefix. If there is none, the error wraps ErrUnknownWallet.
+func FindCATWallet(ctx context.Context, c Caller, assetId string) (*WalletInfo, error) {
+	want := strings.TrimPrefix(normalizeHex(assetId), "0x")
+	for _, t := range []WalletType{CATWallet, CRCATWallet} {
+		ws, err := FindWallets(ctx, c, t)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			if id, ok := w.AssetId(); ok && id == want {
+				return w, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("No CAT wallet for asset %s: %w", assetId, ErrUnknownWallet)
+}
+
+// FindNFTWalletForDID returns the NFT wallet of the DID did, given as "did:chia:1..." or in hex. If there is none, the error wraps ErrUnknownWallet.
+func FindNFTWalletForDID(ctx context.Context, c Caller, did string) (*WalletInfo, error) {
+	want, err := didHex(did)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := FindWallets(ctx, c, NFTWallet)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range ws {
+		id, ok := w.DidId()
+		if !ok && w.Data == "" {
+			// Without the wallet's data, ask for its DID.
+			r, err := (&NftWalletGetDidRequest{WalletId: w.Id}).SendContext(ctx, c)
+			if err != nil {
+				return nil, fmt.Errorf("Couldn't get DID of wallet %d. Error: %w", w.Id, err)
+			}
+			if r.DidId == "" {
+				continue
+			}
+			if id, err = didHex(r.DidId); err != nil {
+				return nil, err
+			}
+		}
+		if id == want {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("No NFT wallet for DID %s: %w", did, ErrUnknownWallet)
+}
+
+// didHex returns the DID did, given as "did:chia:1..." or in hex, in hex with a 0x prefix.
+func didHex(did string) (string, error) {
+	if !strings.HasPrefix(did, "did:") {
+		if _, err := decodeHex(did); err != nil {
+			return "", fmt.Errorf("Invalid DID %q. Error: %w", did, err)
+		}
+		return normalizeHex(did), nil
+	}
+	_, b, err := Bech32mDecode(did)
+	if err != nil {
+		return "", fmt.Errorf("Invalid DID %q. Error: %w", did, err)
+	}
+	return "0x" + hex.EncodeToString(b), nil
+}
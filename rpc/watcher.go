@@ -0,0 +1,320 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CoinEventType is the type of a CoinEvent.
+type CoinEventType int
+
+const (
+	CoinCreated  CoinEventType = iota // A watched coin was created.
+	CoinSpent                         // A watched coin was spent.
+	CoinReverted                      // A coin event delivered earlier was undone by a reorg.
+)
+
+// String implements the fmt.Stringer interface.
+func (t CoinEventType) String() string {
+	switch t {
+	case CoinCreated:
+		return "created"
+	case CoinSpent:
+		return "spent"
+	case CoinReverted:
+		return "reverted"
+	}
+	return fmt.Sprintf("CoinEventType(%d)", int(t))
+}
+
+// A CoinEvent is delivered by a CoinWatcher when a watched coin is created or spent, once the block doing so is confirmed, and when a delivered event is undone by a reorg.
+type CoinEvent struct {
+	Type          CoinEventType
+	Reverted      CoinEventType // For CoinReverted, the type of the event undone.
+	CoinId        string
+	Record        *CoinRecord
+	Height        uint   // Height of the block which created or spent the coin.
+	Confirmations uint   // Number of blocks, including its own, confirming the event when delivered. Zero for CoinReverted.
+	Watched       string // The puzzle hash, coin ID or hint for which the coin is watched. For a coin found by hint, empty if several hints are watched.
+}
+
+// A CoinWatcher follows the chain with a Follower, and delivers events when coins are created at watched puzzle hashes, or with watched hints, and when watched coins, or coins at watched puzzle hashes, are spent.
+// Events are delivered once their block has Confirmations confirmations. If a reorg then removes the block, a CoinReverted event is delivered. Failed polls, such as while the node restarts, are retried; see Follower.Run. Only blocks followed are watched; coins created or spent earlier are not reported.
+type CoinWatcher struct {
+	Follower      *Follower
+	Confirmations uint // Number of confirmations, including the block itself, before an event is delivered. If zero, events are delivered at once.
+	mu            sync.Mutex
+	puzzleHashes  map[string]bool
+	coins         map[string]bool
+	hints         map[string]bool
+	pending       []CoinEvent // Events awaiting confirmations.
+	delivered     []CoinEvent // Delivered events which could yet be undone by a reorg.
+}
+
+// NewCoinWatcher returns a new *CoinWatcher of the full node c, following the chain from the peak.
+func NewCoinWatcher(c Caller) *CoinWatcher {
+	return &CoinWatcher{
+		Follower:     NewFollower(c),
+		puzzleHashes: make(map[string]bool),
+		coins:        make(map[string]bool),
+		hints:        make(map[string]bool),
+	}
+}
+
+// normalizeHex returns h in lower case, with a 0x prefix.
+func normalizeHex(h string) string {
+	return "0x" + strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(h, "0x"), "0X"))
+}
+
+func watch(m map[string]bool, mu *sync.Mutex, hs []string, on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, h := range hs {
+		if on {
+			m[normalizeHex(h)] = true
+		} else {
+			delete(m, normalizeHex(h))
+		}
+	}
+}
+
+// WatchPuzzleHash watches for coins created at, or spent from, the puzzle hashes phs.
+func (w *CoinWatcher) WatchPuzzleHash(phs ...string) {
+	watch(w.puzzleHashes, &w.mu, phs, true)
+}
+
+// WatchCoin watches for the coins with the IDs ids to be created or spent.
+func (w *CoinWatcher) WatchCoin(ids ...string) {
+	watch(w.coins, &w.mu, ids, true)
+}
+
+// WatchHint watches for coins created with the hints hs, and then for their spends.
+func (w *CoinWatcher) WatchHint(hs ...string) {
+	watch(w.hints, &w.mu, hs, true)
+}
+
+// Unwatch stops watching the puzzle hashes, coin IDs or hints hs. Events already awaiting confirmations are still delivered.
+func (w *CoinWatcher) Unwatch(hs ...string) {
+	watch(w.puzzleHashes, &w.mu, hs, false)
+	watch(w.coins, &w.mu, hs, false)
+	watch(w.hints, &w.mu, hs, false)
+}
+
+// Checkpoint returns a checkpoint of the watcher's Follower, for saving before a restart and passing to Resume. It is taken from before the earliest event awaiting confirmations, so that such events are found again after resuming. It may be called while delivering an event, in which case that event, and others of the block being handled, may be delivered again after resuming.
+func (w *CoinWatcher) Checkpoint() map[uint]string {
+	cp := w.Follower.Checkpoint()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, e := range w.pending {
+		for h := range cp {
+			if h >= e.Height {
+				delete(cp, h)
+			}
+		}
+	}
+	return cp
+}
+
+// Resume sets the watcher to continue from a Checkpoint. Watched puzzle hashes, coins and hints are not part of the checkpoint, and must be set again.
+func (w *CoinWatcher) Resume(checkpoint map[uint]string) {
+	w.Follower.Resume(checkpoint)
+}
+
+// Run follows the chain until ctx is done, calling deliver with each event. It returns as Follower.Run does.
+func (w *CoinWatcher) Run(ctx context.Context, deliver func(CoinEvent) error) error {
+	return w.Follower.Run(ctx, func(e BlockEvent) error {
+		return w.handle(ctx, e, deliver)
+	})
+}
+
+// Events runs the watcher until ctx is done, and returns a channel of its events, and a channel which receives the error Run returns. Both are closed when Run returns.
+func (w *CoinWatcher) Events(ctx context.Context) (<-chan CoinEvent, <-chan error) {
+	events, errc := make(chan CoinEvent), make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(events)
+		errc <- w.Run(ctx, func(e CoinEvent) error {
+			select {
+			case events <- e:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return events, errc
+}
+
+// handle turns a BlockEvent into CoinEvents, and delivers those which are confirmed. Events stay pending until delivered successfully, so that if deliver fails, they are delivered when the block is emitted again.
+func (w *CoinWatcher) handle(ctx context.Context, e BlockEvent, deliver func(CoinEvent) error) error {
+	if e.Type == Rollback {
+		return w.rollback(e.ForkHeight, deliver)
+	}
+	found, err := w.match(ctx, e)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	for _, ce := range found {
+		// A block emitted again, after a failed delivery, has events already known.
+		if !w.known(ce) {
+			w.pending = append(w.pending, ce)
+		}
+	}
+	var ready []CoinEvent
+	for _, ce := range w.pending {
+		if c := e.Height - ce.Height + 1; c >= w.Confirmations {
+			ce.Confirmations = c
+			ready = append(ready, ce)
+		}
+	}
+	// Forget delivered events too deep to be reorged.
+	d := w.Follower.ReorgDepth
+	if d == 0 {
+		d = DefaultReorgDepth
+	}
+	delivered := w.delivered[:0]
+	for _, ce := range w.delivered {
+		if ce.Height+d >= e.Height {
+			delivered = append(delivered, ce)
+		}
+	}
+	w.delivered = delivered
+	w.mu.Unlock()
+	for _, ce := range ready {
+		if err := deliver(ce); err != nil {
+			return err
+		}
+		w.mu.Lock()
+		for i, p := range w.pending {
+			if sameEvent(p, ce) {
+				w.pending = append(w.pending[:i], w.pending[i+1:]...)
+				break
+			}
+		}
+		w.delivered = append(w.delivered, ce)
+		w.mu.Unlock()
+	}
+	return nil
+}
+
+// sameEvent reports whether a and b are events of the same coin, type and height.
+func sameEvent(a, b CoinEvent) bool {
+	return a.Type == b.Type && a.CoinId == b.CoinId && a.Height == b.Height
+}
+
+// known reports whether ce is pending or delivered already. The caller must hold w.mu.
+func (w *CoinWatcher) known(ce CoinEvent) bool {
+	for _, es := range [][]CoinEvent{w.pending, w.delivered} {
+		for _, p := range es {
+			if sameEvent(p, ce) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// match returns the events of the watched coins of a block. Coins created with watched hints are then watched as by WatchCoin, so that their spends are found too.
+func (w *CoinWatcher) match(ctx context.Context, e BlockEvent) ([]CoinEvent, error) {
+	w.mu.Lock()
+	var hints []string
+	for h := range w.hints {
+		hints = append(hints, h)
+	}
+	w.mu.Unlock()
+	var hinted []*CoinRecord
+	if len(hints) > 0 && len(e.Additions) > 0 {
+		sort.Strings(hints)
+		// Coin records don't carry their hints, so ask for the block's coins with any of them.
+		r, err := (&CoinRecordsByHintsRequest{Hints: hints, StartHeight: e.Height, EndHeight: e.Height + 1, IncludeSpent: true}).SendContext(ctx, w.Follower.Caller)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't get coin records by hints at height %d. Error: %w", e.Height, err)
+		}
+		hinted = r.CoinRecords
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var found []CoinEvent
+	seen := make(map[string]bool)
+	check := func(t CoinEventType, rs []*CoinRecord) {
+		for _, r := range rs {
+			if r.Coin == nil {
+				continue
+			}
+			id, err := r.Coin.ID()
+			if err != nil || seen[id+t.String()] {
+				continue
+			}
+			ph := normalizeHex(r.Coin.PuzzleHash)
+			watched := ""
+			switch {
+			case w.coins[id]:
+				watched = id
+			case w.puzzleHashes[ph]:
+				watched = ph
+			default:
+				continue
+			}
+			seen[id+t.String()] = true
+			found = append(found, CoinEvent{Type: t, CoinId: id, Record: r, Height: e.Height, Watched: watched})
+		}
+	}
+	check(CoinCreated, e.Additions)
+	// Which hint a coin has can't be told when several are watched.
+	hint := ""
+	if len(hints) == 1 {
+		hint = hints[0]
+	}
+	for _, cr := range hinted {
+		if cr.Coin == nil || cr.ConfirmedBlockIndex != e.Height {
+			continue
+		}
+		id, err := cr.Coin.ID()
+		if err != nil {
+			continue
+		}
+		w.coins[id] = true
+		if seen[id+CoinCreated.String()] {
+			continue
+		}
+		seen[id+CoinCreated.String()] = true
+		found = append(found, CoinEvent{Type: CoinCreated, CoinId: id, Record: cr, Height: e.Height, Watched: hint})
+	}
+	check(CoinSpent, e.Removals)
+	return found, nil
+}
+
+// rollback discards the events of blocks above height fork, delivering CoinReverted events for those already delivered.
+func (w *CoinWatcher) rollback(fork uint, deliver func(CoinEvent) error) error {
+	w.mu.Lock()
+	pending := w.pending[:0]
+	for _, ce := range w.pending {
+		if ce.Height <= fork {
+			pending = append(pending, ce)
+		}
+	}
+	w.pending = pending
+	var reverted []CoinEvent
+	delivered := w.delivered[:0]
+	for _, ce := range w.delivered {
+		if ce.Height <= fork {
+			delivered = append(delivered, ce)
+			continue
+		}
+		ce.Reverted, ce.Type, ce.Confirmations = ce.Type, CoinReverted, 0
+		reverted = append(reverted, ce)
+	}
+	w.delivered = delivered
+	w.mu.Unlock()
+	// Undo the latest first.
+	for i := len(reverted) - 1; i >= 0; i-- {
+		if err := deliver(reverted[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestWatcher(c *fakeChain) *CoinWatcher {
+	w := NewCoinWatcher(c)
+	w.Follower.StartHeight, w.Follower.PollInterval = 1, time.Millisecond
+	w.Confirmations = 2
+	// Coins of odd heights, spent at the next height.
+	w.WatchPuzzleHash(fakePuzzleHash(1))
+	return w
+}
+
+// runWatcher runs f, failing the test if it doesn't return in time, such as when deadlocked.
+func runWatcher(t *testing.T, f func() error) error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() {
+		done <- f()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watcher didn't return; deadlocked?")
+	}
+	return nil
+}
+
+func TestCoinWatcherCheckpointFromDeliver(t *testing.T) {
+	w := newTestWatcher(newFakeChain("a", 6))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var es []CoinEvent
+	var cp map[uint]string
+	err := runWatcher(t, func() error {
+		return w.Run(ctx, func(e CoinEvent) error {
+			es = append(es, e)
+			cp = w.Checkpoint()
+			if len(es) == 4 {
+				cancel()
+			}
+			return nil
+		})
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	want := []CoinEventType{CoinCreated, CoinSpent, CoinCreated, CoinSpent}
+	for i, e := range es {
+		if e.Type != want[i] || e.Height != uint(i+1) || e.Confirmations != 2 {
+			t.Errorf("Unexpected event %d %+v", i, e)
+		}
+	}
+	// The spend at height 4 was being delivered, so the checkpoint is from before it.
+	if _, ok := cp[4]; ok || cp[3] != "a3" {
+		t.Errorf("Unexpected checkpoint %v", cp)
+	}
+}
+
+func TestCoinWatcherCheckpointFromEvents(t *testing.T) {
+	c := newFakeChain("a", 4)
+	w := newTestWatcher(c)
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errc := w.Events(ctx)
+	err := runWatcher(t, func() error {
+		n := 0
+		for e := range events {
+			if cp := w.Checkpoint(); e.Height > 2 && cp[e.Height-2] == "" {
+				t.Errorf("Checkpoint %v lacks block %d", cp, e.Height-2)
+			}
+			if n++; n == 2 {
+				c.extend("a", 2)
+			}
+			if n == 4 {
+				cancel()
+			}
+		}
+		return <-errc
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCoinWatcherHints(t *testing.T) {
+	c := newFakeChain("a", 6)
+	w := NewCoinWatcher(c)
+	w.Follower.StartHeight = 1
+	w.WatchHint(fakeHint, "0x"+strings.Repeat("ee", 32))
+	var es []CoinEvent
+	if err := w.Follower.Poll(context.Background(), func(e BlockEvent) error {
+		return w.handle(context.Background(), e, func(ce CoinEvent) error {
+			es = append(es, ce)
+			return nil
+		})
+	}); err != nil {
+		t.Fatalf("Poll failed: %s", err)
+	}
+	// Coins of even heights are hinted, and spent at the next height.
+	want := []struct {
+		t CoinEventType
+		h uint
+	}{{CoinCreated, 2}, {CoinSpent, 3}, {CoinCreated, 4}, {CoinSpent, 5}}
+	if len(es) != len(want) {
+		t.Fatalf("Unexpected events %+v", es)
+	}
+	for i, e := range es {
+		if e.Type != want[i].t || e.Height != want[i].h {
+			t.Errorf("Unexpected event %d %+v", i, e)
+		}
+	}
+	// One call for all hints, per block.
+	if c.hintCalls != 5 {
+		t.Errorf("Made %d calls by hints for 5 blocks", c.hintCalls)
+	}
+}
+
+func TestCoinWatcherDeliverFails(t *testing.T) {
+	w := newTestWatcher(newFakeChain("a", 6))
+	w.Confirmations = 1
+	fail := errors.New("consumer down")
+	var es []CoinEvent
+	deliver := func(e CoinEvent) error {
+		// Fail the first delivery of the spend at height 2, which comes with no other event.
+		if e.Height == 2 && len(es) == 1 {
+			es = append(es, CoinEvent{})
+			return fail
+		}
+		es = append(es, e)
+		return nil
+	}
+	ctx := context.Background()
+	if err := w.Follower.Poll(ctx, func(e BlockEvent) error { return w.handle(ctx, e, deliver) }); !errors.Is(err, fail) {
+		t.Fatalf("Expected the delivery error, got %v", err)
+	}
+	// The block is emitted again, and the event delivered once.
+	if err := w.Follower.Poll(ctx, func(e BlockEvent) error { return w.handle(ctx, e, deliver) }); err != nil {
+		t.Fatalf("Poll failed: %s", err)
+	}
+	es = slices.DeleteFunc(es, func(e CoinEvent) bool { return e.CoinId == "" })
+	if len(es) != 5 {
+		t.Fatalf("Expected 5 events, got %+v", es)
+	}
+	for i, e := range es {
+		if e.Height != uint(i+1) {
+			t.Errorf("Event %d at height %d", i, e.Height)
+		}
+	}
+}